@@ -31,15 +31,27 @@ const (
 	RPAREN                = "RAPREN"
 	LCURLY                = "LCURLY"
 	RCURLY                = "RCURLY"
+	LSQUARE               = "LSQUARE"
+	RSQUARE               = "RSQUARE"
 	IDENTIFIER            = "IDENTIFIER"
 	ASSIGN                = "ASSIGN"
+	EQUAL                 = "EQUAL"
 	SEMI_COLON            = "SEMI_COLON"
 	COLON                 = "COLON"
 	DOT                   = "DOT"
+	ELLIPSIS              = "ELLIPSIS"
+	RANGE                 = "RANGE"
+	FAT_ARROW             = "FAT_ARROW"
+	WILDCARD              = "WILDCARD"
+	QUESTION              = "QUESTION"
+	COALESCE              = "COALESCE"
 	BLANK                 = "BLANK"
 	COMMA                 = "COMMA"
 	SINGLE_QUOTE          = "SINGLE_QUOTE"
 	DOUBLE_QOUTE          = "DOUBLE_QOUTE"
+	ARROW                 = "ARROW"
+	LIST                  = "LIST"
+	FUNCTION              = "FUNCTION"
 )
 
 const (
@@ -60,26 +72,38 @@ const (
 	LCURLY_SYMBOL                = "{"
 	RPAREN_SYMBOL                = ")"
 	RCURLY_SYMBOL                = "}"
+	LSQUARE_SYMBOL               = "["
+	RSQUARE_SYMBOL               = "]"
 	EQUAL_SYMBOL                 = "="
 	COLON_SYMBOL                 = ":"
 	SEMI_COLON_SYMBOL            = ";"
 	DOT_SYMBOL                   = "."
+	ELLIPSIS_SYMBOL              = "..."
+	RANGE_SYMBOL                 = ".."
+	FAT_ARROW_SYMBOL             = "=>"
+	WILDCARD_SYMBOL              = "_"
+	QUESTION_SYMBOL              = "?"
+	COALESCE_SYMBOL              = "??"
 	EXCLAMATION_SYMBOL           = "!"
 	ASSIGN_SYMBOL                = ":="
 	COMMENT_SYMBOL               = "#"
 	COMMA_SYMBOL                 = ","
 	SINGLE_QUOTE_SYMBOL          = "\""
 	DOUBLE_QOUTE_SYMBOL          = "'"
+	BACKSLASH_SYMBOL             = "\\"
+	ARROW_SYMBOL                 = "->"
 )
 
 // keywords
 const (
 	LET          = "let"
+	CONST        = "const"
 	INTEGER_TYPE = "int"
 	FLOAT_TYPE   = "float"
 	STRING_TYPE  = "str"
 	BOOLEAN_TYPE = "bool"
 	DEFINE       = "define"
+	FN           = "fn"
 	IF           = "if"
 	ELSE_IF      = "elif"
 	ELSE         = "else"
@@ -93,6 +117,10 @@ const (
 	RETURN       = "return"
 	TRUE         = "true"
 	FALSE        = "false"
+	NONE         = "none"
+	ENUM         = "enum"
+	MATCH        = "match"
+	THEN         = "then"
 )
 
 // symbol types
@@ -100,21 +128,97 @@ const (
 	BUILT_IN_TYPE = "BUILT_IN_TYPE"
 	VARIABLE_TYPE = "VARIABLE_TYPE"
 	FUNCTION_TYPE = "FUNCTION_TYPE"
+	ENUM_TYPE     = "ENUM_TYPE" // types declared with enum, ex - enum Color { Red, Green, Blue }
+
+	// only used to describe the parameters of built in functions, accepts an int or a float
+	NUMBER_TYPE = "number"
+
+	// the type of the none literal, it can only be stored in optional types, ex - int?
+	NONE_TYPE = NONE
+)
+
+// named arguments of print and println, ex - print("a", 1, sep = ",", end = "\n")
+const (
+	SEPARATOR = "sep"
+	LINE_END  = "end"
+)
+
+// symbol categories
+const (
+	CONSTANT_CATEGORY = "CONSTANT_CATEGORY"
+	FUNCTION_CATEGORY = "FUNCTION_CATEGORY" // functions declared with define
 )
 
 // predefined functions
 const (
-	PRINT_OUTPUT = "output"
+	PRINT_OUTPUT  = "output"
+	ARGS          = "args"
+	ENV           = "env"
+	EXIT          = "exit"
+	LENGTH        = "len"
+	PRINT         = "print"
+	PRINT_LINE    = "println"
+	PRINT_FORMAT  = "printf"
+	SPRINT_FORMAT = "sprintf"
+	INPUT         = "input"
+	READ_LINE     = "read_line"
+	READ_ALL      = "read_all"
+	END_OF_INPUT  = "eof"
+	PARSE_INT     = "parse_int"
+	PARSE_FLOAT   = "parse_float"
+	READ_FILE     = "read_file"
+	WRITE_FILE    = "write_file"
+	APPEND_FILE   = "append_file"
+	LIST_DIR      = "list_dir"
+	FILE_EXISTS   = "file_exists"
+	SQRT          = "sqrt"
+	ABS           = "abs"
+	FLOOR         = "floor"
+	CEIL          = "ceil"
+	ROUND         = "round"
+	MIN           = "min"
+	MAX           = "max"
+	POW           = "pow"
+	LOG           = "log"
+	EXP           = "exp"
+	SIN           = "sin"
+	COS           = "cos"
+	TAN           = "tan"
+	ATAN2         = "atan2"
+	ORDINAL       = "ordinal"
+)
+
+// the member of an enum with an ordinal, ex - Color.from_ordinal(2)
+const FROM_ORDINAL = "from_ordinal"
+
+// predefined constants
+const (
+	PI = "PI"
+	E  = "E"
 )
 
 // error codes
 const (
-	ERROR_UNEXPECTED_TOKEN     = "Unexpected Token"
-	ERROR_ID_NOT_FOUND         = "Identifier not found"
-	ERROR_DUPLICATE_ID         = "Duplicate identifier found"
-	ERROR_VARAIBLE_NOT_DEFINED = "Variable not defined"
-	INVALID_SYNTAX             = "Invalid Syntax"
-	LOGICAL_ERROR              = "Logical Error"
+	ERROR_UNEXPECTED_TOKEN       = "Unexpected Token"
+	ERROR_ID_NOT_FOUND           = "Identifier not found"
+	ERROR_DUPLICATE_ID           = "Duplicate identifier found"
+	ERROR_VARAIBLE_NOT_DEFINED   = "Variable not defined"
+	ERROR_INDEX_OUT_OF_RANGE     = "Index out of range"
+	ERROR_INVALID_ARGUMENT       = "Invalid argument"
+	ERROR_INVALID_FORMAT         = "Invalid format"
+	ERROR_INPUT                  = "Input error"
+	ERROR_FILE_ACCESS            = "File access error"
+	ERROR_ASSIGN_TO_CONSTANT     = "Assignment to constant"
+	ERROR_WRONG_ARGUMENTS        = "Wrong arguments"
+	ERROR_CONVERSION             = "Conversion error"
+	ERROR_TYPE_INFERENCE         = "Cannot infer type"
+	ERROR_TYPE_MISMATCH          = "Type mismatch"
+	ERROR_USED_BEFORE_ASSIGNMENT = "Used before assignment"
+	ERROR_NOT_CONSTANT           = "Not a constant expression"
+	ERROR_UNSUPPORTED            = "Not supported"
+	ERROR_CALL_DEPTH             = "Call depth exceeded"
+	INVALID_SYNTAX               = "Invalid Syntax"
+	LOGICAL_ERROR                = "Logical Error"
 )
 
 // error types
@@ -134,9 +238,6 @@ const (
 	AR_IF       = "AR_IF"
 	AR_ELSE_IF  = "AR_ELSE_IF"
 	AR_ELSE     = "AR_ELSE"
-
-	AR_KEY_TYPE  = "varType"
-	AR_KEY_VALUE = "varValue"
 )
 
 /*
@@ -156,6 +257,11 @@ var RESERVED = map[string]types.Token{
 		Value: LET,
 	},
 
+	CONST: {
+		Type:  CONST,
+		Value: CONST,
+	},
+
 	INTEGER_TYPE: {
 		Type:  INTEGER_TYPE,
 		Value: INTEGER_TYPE,
@@ -181,6 +287,11 @@ var RESERVED = map[string]types.Token{
 		Value: DEFINE,
 	},
 
+	FN: {
+		Type:  FN,
+		Value: FN,
+	},
+
 	AND: {
 		Type:  AND,
 		Value: AND,
@@ -245,6 +356,36 @@ var RESERVED = map[string]types.Token{
 		Type:  FALSE,
 		Value: FALSE,
 	},
+
+	NONE: {
+		Type:  NONE,
+		Value: NONE,
+	},
+
+	ENUM: {
+		Type:  ENUM,
+		Value: ENUM,
+	},
+
+	MATCH: {
+		Type:  MATCH,
+		Value: MATCH,
+	},
+
+	THEN: {
+		Type:  THEN,
+		Value: THEN,
+	},
+}
+
+// characters following a backslash inside a string literal, and what they stand for
+var ESCAPE_SEQUENCES = map[string]string{
+	"n":                 "\n",
+	"t":                 "\t",
+	"r":                 "\r",
+	BACKSLASH_SYMBOL:    BACKSLASH_SYMBOL,
+	SINGLE_QUOTE_SYMBOL: SINGLE_QUOTE_SYMBOL,
+	DOUBLE_QOUTE_SYMBOL: DOUBLE_QOUTE_SYMBOL,
 }
 
 var PLUS_MINUS_SLICE = []string{PLUS, MINUS}
@@ -252,10 +393,35 @@ var MUL_DIV_SLICE = []string{MUL, INTEGER_DIV, FLOAT_DIV, MODULO, EXPONENT}
 var COMPARATORS_SLICE = []string{GREATER_THAN, LESS_THAN, GREATER_THAN_EQUAL_TO, LESS_THAN_EQUAL_TO, EQUALITY, NOT_EQUAL_TO}
 var LOGICAL_OPERANDS_SLICE = []string{AND, OR, NOT}
 var CONDITIONAL_KEYWORDS = []string{ELSE_IF, ELSE}
+var DECLARATION_KEYWORDS = []string{LET, CONST, DEFINE, ENUM}
 var QUOTES_SLICE = []string{DOUBLE_QOUTE_SYMBOL, SINGLE_QUOTE_SYMBOL}
 
 var SpewPrinter = spew.ConfigState{Indent: "    "}
 
+// color modes for the interpreter's output
+const (
+	COLOR_AUTO   = "auto"
+	COLOR_ALWAYS = "always"
+	COLOR_NEVER  = "never"
+
+	NO_COLOR_ENV = "NO_COLOR"
+)
+
+// engines running the program, the tree walker is the reference implementation
+const (
+	ENGINE_TREE = "tree"
+	ENGINE_VM   = "vm"
+)
+
+// optimization levels of the interpreter
+const (
+	OPT_LEVEL_NONE = 0
+	OPT_LEVEL_FULL = 1
+)
+
+// how many function calls can be running at once, deeper recursion is a RuntimeError in both engines
+const MAX_CALL_DEPTH = 5000
+
 // colors
 const (
 	Black   = "\u001b[30;1m"
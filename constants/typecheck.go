@@ -74,6 +74,9 @@ var ALLOWED_OPERATIONS_ON_TYPES = map[string]map[string]map[string]bool{
 		STRING: {
 			STRING: true,
 		},
+		LIST: {
+			LIST: true,
+		},
 	},
 	MUL: {
 		INTEGER: {
@@ -106,3 +109,29 @@ var ALLOWED_OPERATIONS_ON_TYPES = map[string]map[string]map[string]bool{
 	EQUALITY:     INT_FLOAT_STRING_BOOL_OPERATIONS,
 	NOT_EQUAL_TO: INT_FLOAT_STRING_BOOL_OPERATIONS,
 }
+
+/*
+	The argument types accepted by each printf verb. The "v" verb accepts anything
+
+	Ex - printf("%5.2f", 3.14159) prints " 3.14"
+*/
+var FORMAT_VERB_TYPES = map[string]map[string]bool{
+	"d": {INTEGER_TYPE: true},
+	"b": {INTEGER_TYPE: true},
+	"o": {INTEGER_TYPE: true},
+	"c": {INTEGER_TYPE: true},
+	"x": {INTEGER_TYPE: true, STRING_TYPE: true},
+	"X": {INTEGER_TYPE: true, STRING_TYPE: true},
+	"f": {FLOAT_TYPE: true, INTEGER_TYPE: true},
+	"F": {FLOAT_TYPE: true, INTEGER_TYPE: true},
+	"e": {FLOAT_TYPE: true, INTEGER_TYPE: true},
+	"E": {FLOAT_TYPE: true, INTEGER_TYPE: true},
+	"g": {FLOAT_TYPE: true, INTEGER_TYPE: true},
+	"G": {FLOAT_TYPE: true, INTEGER_TYPE: true},
+	"s": {STRING_TYPE: true},
+	"q": {STRING_TYPE: true},
+	"t": {BOOLEAN_TYPE: true},
+}
+
+const FORMAT_ANY_VERB = "v"
+const FORMAT_FLAGS = "+-# 0"
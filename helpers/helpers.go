@@ -2,6 +2,8 @@ package helpers
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"reflect"
 	"unicode"
 
@@ -19,12 +21,18 @@ func ValueInSlice(value string, list []string) bool {
 }
 
 func IsAlphaNum(value byte) bool {
-	return unicode.IsLetter(rune(value)) || unicode.IsDigit(rune(value))
+	return unicode.IsLetter(rune(value)) || unicode.IsDigit(rune(value)) || value == '_'
 }
 
 func GetFloat(value interface{}) (float32, bool) {
 	v := reflect.ValueOf(value)
 	v = reflect.Indirect(v)
+
+	// nil values, ex - a variable that was never assigned
+	if !v.IsValid() {
+		return 0.0, false
+	}
+
 	var floatType = reflect.TypeOf(float32(0))
 
 	// ColorPrint(constants.Blue, 1, 1, "reflect.ValueOf(value) = ", v, " value = ", value)
@@ -51,3 +59,28 @@ func ColorPrint(color string, newLinesTop int, newLinesBottom int, toPrint ...in
 	fmt.Print(toPrint...)
 	fmt.Print(nlb, constants.Reset)
 }
+
+// same as ColorPrint but writes to the given writer, and only adds the color codes if useColor is set
+func ColorFprint(writer io.Writer, useColor bool, color string, toPrint ...interface{}) {
+	if !useColor {
+		fmt.Fprint(writer, toPrint...)
+		return
+	}
+
+	fmt.Fprint(writer, color)
+	fmt.Fprint(writer, toPrint...)
+	fmt.Fprint(writer, constants.Reset)
+}
+
+// whether the writer is a terminal (character device) as opposed to a file, pipe or buffer
+func IsTerminal(writer io.Writer) bool {
+	file, ok := writer.(*os.File)
+
+	if !ok {
+		return false
+	}
+
+	info, err := file.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,147 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"programminglang/constants"
+	"programminglang/interpreter/errors"
+)
+
+// identifies a variable by the scope it's declared in and its name
+type variableKey struct {
+	scope *ScopedSymbolsTable
+	name  string
+}
+
+/*
+	Definite assignment analysis, done while scoping.
+
+	Only variables declared without an initializer are tracked. Reading one that is not assigned on any
+	path is a semantic error. If it's only assigned on some of the paths, ex - inside an if block, the
+	interpreter checks at runtime instead
+*/
+type AssignmentAnalysis struct {
+	tracked  map[variableKey]bool
+	definite map[variableKey]bool // assigned on every path leading to the current statement
+	possible map[variableKey]bool // assigned on at least one path, anywhere
+
+	// level of the innermost function scope. Variables declared outside the function could be assigned
+	// before the function is called, so they are only checked at runtime
+	functionScopeLevel int
+
+	// the names of the variables assigned to inside any function of the program, which a call could make none again
+	inFunctions map[string]bool
+}
+
+func (a *AssignmentAnalysis) Init() {
+	a.tracked = map[variableKey]bool{}
+	a.definite = map[variableKey]bool{}
+	a.possible = map[variableKey]bool{}
+	a.inFunctions = map[string]bool{}
+}
+
+func (a *AssignmentAnalysis) snapshot() map[variableKey]bool {
+	copied := map[variableKey]bool{}
+
+	for key := range a.definite {
+		copied[key] = true
+	}
+
+	return copied
+}
+
+func (a *AssignmentAnalysis) restore(definite map[variableKey]bool) {
+	a.definite = map[variableKey]bool{}
+
+	for key := range definite {
+		a.definite[key] = true
+	}
+}
+
+// keeps only the variables assigned on every one of the paths
+func intersectAssignments(paths []map[variableKey]bool) map[variableKey]bool {
+	result := map[variableKey]bool{}
+
+	for key := range paths[0] {
+		inAll := true
+
+		for _, path := range paths[1:] {
+			if !path[key] {
+				inAll = false
+				break
+			}
+		}
+
+		if inAll {
+			result[key] = true
+		}
+	}
+
+	return result
+}
+
+func (i *Interpreter) DeclareUnassigned(name string) {
+	i.Assignments.tracked[variableKey{scope: i.CurrentScope, name: name}] = true
+}
+
+func (i *Interpreter) MarkAssigned(name string) {
+	scope, _, exists := i.CurrentScope.LookupSymbolScope(name)
+
+	if !exists {
+		return
+	}
+
+	key := variableKey{scope: scope, name: name}
+
+	i.Assignments.definite[key] = true
+	i.Assignments.possible[key] = true
+}
+
+// errors if the variable is read before being assigned a value on every path
+func (i *Interpreter) CheckAssigned(v Variable) {
+	scope, _, exists := i.CurrentScope.LookupSymbolScope(v.Value)
+
+	if !exists {
+		return
+	}
+
+	key := variableKey{scope: scope, name: v.Value}
+	a := i.Assignments
+
+	if !a.tracked[key] || a.definite[key] || a.possible[key] || scope.CurrentScopeLevel < a.functionScopeLevel {
+		return
+	}
+
+	errors.ShowError(
+		constants.SEMANTIC_ERROR,
+		constants.ERROR_USED_BEFORE_ASSIGNMENT,
+		fmt.Sprintf("variable '%s' used before assignment", v.Value),
+		v.Token,
+	)
+}
+
+/*
+	A loop body can read a variable in one iteration that is assigned later on in a previous iteration,
+	so everything assigned in the body is possibly assigned before the body is scoped
+*/
+func (i *Interpreter) MarkPossiblyAssignedIn(block AbstractSyntaxTree) {
+	WalkTree(block, func(node AbstractSyntaxTree) bool {
+		var targets []AbstractSyntaxTree
+
+		switch n := node.(type) {
+		case AssignmentStatement:
+			targets = append(targets, n.Left)
+
+		case DestructuringAssignment:
+			targets = append(targets, n.Targets...)
+		}
+
+		for _, target := range targets {
+			if scope, _, exists := i.CurrentScope.LookupSymbolScope(target.GetToken().Value); exists {
+				i.Assignments.possible[variableKey{scope: scope, name: target.GetToken().Value}] = true
+			}
+		}
+
+		return true
+	})
+}
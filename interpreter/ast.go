@@ -8,3 +8,249 @@ type AbstractSyntaxTree interface {
 	GetToken() types.Token
 	Scope(i *Interpreter)
 }
+
+// the direct children of a node, in the order they are evaluated
+func Children(node AbstractSyntaxTree) []AbstractSyntaxTree {
+	var children []AbstractSyntaxTree
+
+	add := func(nodes ...AbstractSyntaxTree) {
+		for _, child := range nodes {
+			if child != nil {
+				children = append(children, child)
+			}
+		}
+	}
+
+	switch n := node.(type) {
+	case Program:
+		add(n.Declarations...)
+		add(n.CompoundStatement)
+
+	case CompoundStatement:
+		add(n.Children...)
+
+	case AssignmentStatement:
+		add(n.Left, n.Right)
+
+	case DestructuringAssignment:
+		add(n.Targets...)
+		add(n.Right)
+
+	case VariableDeclaration:
+		add(n.Initializer)
+
+	case FunctionDeclaration:
+		add(n.FunctionBlock, n.ReturningValue)
+
+	case FunctionLiteral:
+		add(n.FunctionBlock, n.ReturningValue)
+
+	case FunctionCall:
+		add(n.ActualParameters...)
+
+	case ListLiteral:
+		add(n.Elements...)
+
+	case TupleLiteral:
+		add(n.Elements...)
+
+	case BinaryOperationNode:
+		add(n.Left, n.Right)
+
+	case UnaryOperationNode:
+		add(n.Operand)
+
+	case ComparisonNode:
+		add(n.Left, n.Right)
+
+	case LogicalNode:
+		add(n.Left, n.Right)
+
+	case CoalesceNode:
+		add(n.Left, n.Right)
+
+	case ConditionalExpression:
+		add(n.Condition, n.WhenTrue, n.WhenFalse)
+
+	case ConditionalStatement:
+		add(n.Conditionals, n.ConditionalBlock)
+
+		for _, statement := range n.Ladder {
+			add(statement.Conditionals, statement.ConditionalBlock)
+		}
+
+	case MatchStatement:
+		add(n.Subject)
+
+		for _, arm := range n.Arms {
+			add(arm.Guard, arm.Block)
+		}
+
+	case RangeLoop:
+		add(n.Low, n.High, n.Block)
+
+	case IndexNode:
+		add(n.Left, n.Index)
+
+	case TypeConversion:
+		add(n.Argument)
+
+	case EnumFromOrdinal:
+		add(n.Argument)
+	}
+
+	return children
+}
+
+// visits every node of the tree depth first, stops descending into a node's children if visit returns false
+func WalkTree(node AbstractSyntaxTree, visit func(AbstractSyntaxTree) bool) {
+	if node == nil || !visit(node) {
+		return
+	}
+
+	for _, child := range Children(node) {
+		WalkTree(child, visit)
+	}
+}
+
+/*
+	Returns a copy of the node with each of its children replaced by transform(child).
+
+	Only the children which are evaluated are transformed, not the variables being declared or assigned to
+*/
+func MapChildren(node AbstractSyntaxTree, transform func(AbstractSyntaxTree) AbstractSyntaxTree) AbstractSyntaxTree {
+	mapped := func(child AbstractSyntaxTree) AbstractSyntaxTree {
+		if child == nil {
+			return nil
+		}
+
+		return transform(child)
+	}
+
+	mappedAll := func(children []AbstractSyntaxTree) []AbstractSyntaxTree {
+		var result []AbstractSyntaxTree
+
+		for _, child := range children {
+			result = append(result, mapped(child))
+		}
+
+		return result
+	}
+
+	switch n := node.(type) {
+	case Program:
+		n.Declarations = mappedAll(n.Declarations)
+		n.CompoundStatement = mapped(n.CompoundStatement)
+		return n
+
+	case CompoundStatement:
+		n.Children = mappedAll(n.Children)
+		return n
+
+	case AssignmentStatement:
+		n.Right = mapped(n.Right)
+		return n
+
+	case DestructuringAssignment:
+		n.Right = mapped(n.Right)
+		return n
+
+	case VariableDeclaration:
+		n.Initializer = mapped(n.Initializer)
+		return n
+
+	case FunctionDeclaration:
+		n.FunctionBlock = mapped(n.FunctionBlock)
+		n.ReturningValue = mapped(n.ReturningValue)
+		return n
+
+	case FunctionLiteral:
+		n.FunctionBlock = mapped(n.FunctionBlock)
+		n.ReturningValue = mapped(n.ReturningValue)
+		return n
+
+	case FunctionCall:
+		n.ActualParameters = mappedAll(n.ActualParameters)
+		return n
+
+	case ListLiteral:
+		n.Elements = mappedAll(n.Elements)
+		return n
+
+	case TupleLiteral:
+		n.Elements = mappedAll(n.Elements)
+		return n
+
+	case BinaryOperationNode:
+		n.Left, n.Right = mapped(n.Left), mapped(n.Right)
+		return n
+
+	case UnaryOperationNode:
+		n.Operand = mapped(n.Operand)
+		return n
+
+	case ComparisonNode:
+		n.Left, n.Right = mapped(n.Left), mapped(n.Right)
+		return n
+
+	case LogicalNode:
+		n.Left, n.Right = mapped(n.Left), mapped(n.Right)
+		return n
+
+	case CoalesceNode:
+		n.Left, n.Right = mapped(n.Left), mapped(n.Right)
+		return n
+
+	case ConditionalExpression:
+		n.Condition, n.WhenTrue, n.WhenFalse = mapped(n.Condition), mapped(n.WhenTrue), mapped(n.WhenFalse)
+		return n
+
+	case ConditionalStatement:
+		n.Conditionals = mapped(n.Conditionals)
+		n.ConditionalBlock = mapped(n.ConditionalBlock)
+
+		var ladder []ConditionalStatement
+
+		// the elif and else statements are only parts of this one, so their children are mapped instead
+		for _, statement := range n.Ladder {
+			statement.Conditionals = mapped(statement.Conditionals)
+			statement.ConditionalBlock = mapped(statement.ConditionalBlock)
+			ladder = append(ladder, statement)
+		}
+
+		n.Ladder = ladder
+		return n
+
+	// the patterns are literals already
+	case MatchStatement:
+		n.Subject = mapped(n.Subject)
+
+		var arms []MatchArm
+
+		for _, arm := range n.Arms {
+			arm.Guard, arm.Block = mapped(arm.Guard), mapped(arm.Block)
+			arms = append(arms, arm)
+		}
+
+		n.Arms = arms
+		return n
+
+	case RangeLoop:
+		n.Low, n.High, n.Block = mapped(n.Low), mapped(n.High), mapped(n.Block)
+		return n
+
+	case IndexNode:
+		n.Left, n.Index = mapped(n.Left), mapped(n.Index)
+		return n
+
+	case TypeConversion:
+		n.Argument = mapped(n.Argument)
+		return n
+
+	case EnumFromOrdinal:
+		n.Argument = mapped(n.Argument)
+		return n
+	}
+
+	return node
+}
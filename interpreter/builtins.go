@@ -0,0 +1,312 @@
+package interpreter
+
+import (
+	"fmt"
+	"os"
+
+	"programminglang/constants"
+	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
+)
+
+// a function implemented in Go which is callable from within a script
+type NativeFunction struct {
+	Name       string
+	ReturnType string   // constants.NUMBER_TYPE means int if all the arguments are ints, float otherwise
+	ParamTypes []string // if set, the call must have exactly these parameter types. Checked while scoping
+
+	// the names and types of the optional arguments which are passed by name, after the positional ones
+	NamedParams map[string]string
+
+	// args are the already evaluated actual parameters of the function call f
+	Call func(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value
+
+	// optional, called while scoping with the statically known types of the actual parameters
+	Check func(i *Interpreter, f FunctionCall, argTypes []string)
+}
+
+// all the native functions, keyed by name. Every scope gets a symbol for each of these
+var NativeFunctions = map[string]*NativeFunction{}
+
+func RegisterNativeFunction(native NativeFunction) {
+	NativeFunctions[native.Name] = &native
+}
+
+// predefined values which cannot be assigned to, ex - PI
+var NativeConstants = map[string]runtime.Value{}
+
+func RegisterNativeConstant(name string, value runtime.Value) {
+	NativeConstants[name] = value
+}
+
+// used to unwind the interpreter when a script calls exit(code)
+type exitSignal struct {
+	code int
+}
+
+func init() {
+	RegisterNativeFunction(NativeFunction{
+		Name: constants.PRINT_OUTPUT,
+		Call: nativeOutput,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:       constants.ARGS,
+		ReturnType: ListTypeOf(constants.STRING_TYPE),
+		Call:       nativeArgs,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:       constants.ENV,
+		ReturnType: constants.STRING_TYPE,
+		Call:       nativeEnv,
+		Check:      checkEnvCall,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:  constants.EXIT,
+		Call:  nativeExit,
+		Check: checkExitCall,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:       constants.LENGTH,
+		ReturnType: constants.INTEGER_TYPE,
+		Call:       nativeLength,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:        constants.PRINT,
+		NamedParams: printParams,
+		Call:        nativePrint,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:        constants.PRINT_LINE,
+		NamedParams: printParams,
+		Call:        nativePrintLine,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:  constants.PRINT_FORMAT,
+		Call:  nativePrintFormat,
+		Check: checkFormatCall,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:       constants.SPRINT_FORMAT,
+		ReturnType: constants.STRING_TYPE,
+		Call:       nativeSprintFormat,
+		Check:      checkFormatCall,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:       constants.INPUT,
+		ReturnType: constants.STRING_TYPE,
+		Call:       nativeInput,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:       constants.READ_LINE,
+		ReturnType: constants.STRING_TYPE,
+		Call:       nativeReadLine,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:       constants.READ_ALL,
+		ReturnType: constants.STRING_TYPE,
+		Call:       nativeReadAll,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:       constants.END_OF_INPUT,
+		ReturnType: constants.BOOLEAN_TYPE,
+		Call:       nativeEndOfInput,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:       constants.PARSE_INT,
+		ReturnType: constants.INTEGER_TYPE,
+		Call:       nativeParseInt,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:       constants.PARSE_FLOAT,
+		ReturnType: constants.FLOAT_TYPE,
+		Call:       nativeParseFloat,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:       constants.READ_FILE,
+		ReturnType: constants.STRING_TYPE,
+		Call:       nativeReadFile,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name: constants.WRITE_FILE,
+		Call: nativeWriteFile,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name: constants.APPEND_FILE,
+		Call: nativeAppendFile,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:       constants.LIST_DIR,
+		ReturnType: ListTypeOf(constants.STRING_TYPE),
+		Call:       nativeListDir,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:       constants.FILE_EXISTS,
+		ReturnType: constants.BOOLEAN_TYPE,
+		Call:       nativeFileExists,
+	})
+}
+
+func nativeOutput(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	for index, param := range f.ActualParameters {
+		color := constants.LightYellow
+
+		// comparisons might have been folded into a boolean
+		if args[index].Kind == runtime.BOOL {
+			color = constants.LightCyan
+		}
+
+		if _, ok := param.(String); ok {
+			color = constants.LightGreen
+		}
+
+		i.ColorPrint(color, args[index])
+	}
+
+	fmt.Fprintln(i.GetStdout())
+
+	return runtime.Nil
+}
+
+// args() returns the command line arguments passed after the script path
+func nativeArgs(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	list := []runtime.Value{}
+
+	for _, arg := range i.Args {
+		list = append(list, runtime.StringValue(arg))
+	}
+
+	return runtime.ListValue(list)
+}
+
+// env(name) or env(name, default)
+func nativeEnv(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	if len(args) == 0 || len(args) > 2 {
+		errors.ShowError(
+			constants.RUNTIME_ERROR,
+			constants.ERROR_INVALID_ARGUMENT,
+			fmt.Sprintf("%s expects a name and an optional default value", constants.ENV),
+			f.Token,
+		)
+	}
+
+	for _, arg := range args {
+		if arg.Kind != runtime.STRING {
+			errors.ShowError(
+				constants.RUNTIME_ERROR,
+				constants.ERROR_INVALID_ARGUMENT,
+				fmt.Sprintf("%s expects strings, got '%v'", constants.ENV, arg),
+				f.Token,
+			)
+		}
+	}
+
+	name := args[0].Str
+
+	if value, ok := i.LookupEnv(name); ok {
+		return runtime.StringValue(value)
+	}
+
+	if len(args) == 2 {
+		return args[1]
+	}
+
+	return runtime.StringValue("")
+}
+
+// exit(code) stops the script, code defaults to 0
+func nativeExit(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	code := 0
+
+	if len(args) > 0 {
+		if args[0].Kind != runtime.INT {
+			errors.ShowError(
+				constants.RUNTIME_ERROR,
+				constants.ERROR_INVALID_ARGUMENT,
+				fmt.Sprintf("%s expects an integer exit code, got '%v'", constants.EXIT, args[0]),
+				f.Token,
+			)
+		}
+
+		code = args[0].Int
+	}
+
+	panic(exitSignal{code: code})
+}
+
+// the name and the default of env have to be strings, so a call always returns one
+func checkEnvCall(i *Interpreter, f FunctionCall, argTypes []string) {
+	if len(argTypes) == 0 || len(argTypes) > 2 {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_WRONG_ARGUMENTS,
+			fmt.Sprintf("%s expects a name and an optional default value", f.FunctionName),
+			f.Token,
+		)
+	}
+
+	checkNativeParamTypes(f, []string{constants.STRING_TYPE, constants.STRING_TYPE}[:len(argTypes)], argTypes)
+}
+
+func checkExitCall(i *Interpreter, f FunctionCall, argTypes []string) {
+	if len(argTypes) > 1 {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_WRONG_ARGUMENTS,
+			fmt.Sprintf("%s expects an optional exit code", f.FunctionName),
+			f.Token,
+		)
+	}
+
+	checkNativeParamTypes(f, []string{constants.INTEGER_TYPE}[:len(argTypes)], argTypes)
+}
+
+// len(list) or len(string)
+func nativeLength(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	if len(args) == 1 {
+		switch args[0].Kind {
+		case runtime.LIST:
+			return runtime.IntValue(len(args[0].List))
+
+		case runtime.STRING:
+			return runtime.IntValue(len(args[0].Str))
+		}
+	}
+
+	errors.ShowError(
+		constants.RUNTIME_ERROR,
+		constants.ERROR_INVALID_ARGUMENT,
+		fmt.Sprintf("%s expects a single list or string", constants.LENGTH),
+		f.Token,
+	)
+
+	return runtime.Nil
+}
+
+// looks up an environment variable in the injected Env, or the process environment if Env is nil
+func (i *Interpreter) LookupEnv(name string) (string, bool) {
+	if i.Env != nil {
+		value, ok := i.Env[name]
+		return value, ok
+	}
+
+	return os.LookupEnv(name)
+}
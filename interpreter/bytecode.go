@@ -0,0 +1,109 @@
+package interpreter
+
+import "programminglang/interpreter/runtime"
+
+// an instruction of the virtual machine, what A and B mean depends on the Op
+type Opcode byte
+
+const (
+	OP_CONSTANT         Opcode = iota // push Constants[A]
+	OP_NIL                            // push nil
+	OP_POP                            // discard the top of the stack
+	OP_GET_LOCAL                      // push slot A of the current environment, Nodes[B] is the Variable read
+	OP_SET_LOCAL                      // pop into slot A of the current environment
+	OP_GET_GLOBAL                     // push slot A of the main program's frame, Nodes[B] is the Variable read
+	OP_SET_GLOBAL                     // pop into slot A of the main program's frame
+	OP_GET_OUTER                      // push slot A of the environment C levels up, Nodes[B] is the Variable read
+	OP_SET_OUTER                      // pop into slot A of the environment C levels up
+	OP_TO_FLOAT                       // convert the int on top of the stack to a float, or the ints of a list spread into a variadic float parameter. With B, the int at B - 1 in the tuple on top
+	OP_UNARY                          // apply the UnaryOperationNode Nodes[A] to the top of the stack
+	OP_BINARY                         // apply the BinaryOperationNode Nodes[A] to the top two values
+	OP_COMPARE                        // apply the ComparisonNode Nodes[A] to the top two values
+	OP_LOGICAL                        // apply the LogicalNode Nodes[A] to the top two values
+	OP_INDEX                          // apply the IndexNode Nodes[A] to the top two values
+	OP_CONVERT                        // apply the TypeConversion Nodes[A] to the top of the stack
+	OP_FROM_ORDINAL                   // replace the int on top of the stack with the member of the EnumFromOrdinal Nodes[A]
+	OP_JUMP                           // continue at instruction A
+	OP_JUMP_IF_FALSE                  // pop, continue at instruction A unless it was true
+	OP_JUMP_IF_NOT_NONE               // continue at instruction A keeping the top of the stack, unless it's none which is popped
+	OP_JUMP_IF_DECIDED                // continue at instruction A keeping the top of the stack if it's the result of the LogicalNode Nodes[B] on its own
+	OP_MATCH                          // replace the top of the stack with whether a pattern of arm B of the MatchStatement Nodes[A] matches it
+	OP_LOOP_BOUND                     // truncate the number on top of the stack to an int
+	OP_LOOP_TEST                      // continue at instruction B if the counter in slot A is past the bound in slot A + 1
+	OP_INCREMENT                      // add one to the int in slot A
+	OP_ENTER                          // run in a new environment with A slots, enclosed by the current one
+	OP_LEAVE                          // go back to the environment enclosing the current one
+	OP_CLOSURE                        // push Functions[A] as a value, enclosed by the environment C levels up
+	OP_CALL                           // call Functions[A] with its parameters' number of values on top as arguments, enclosed by the environment C levels up. Nodes[B] is the FunctionCall
+	OP_ARRANGE                        // put the top B values in the order of the parameters of the FunctionCall Nodes[A]
+	OP_CALL_NATIVE                    // call the built in function of the FunctionCall Nodes[A]
+	OP_CALL_VALUE                     // pop a function value and call it with the top B values, Nodes[A] is the FunctionCall
+	OP_LIST                           // replace the top A values with a list of them
+	OP_TUPLE                          // replace the top A values with a tuple of them
+	OP_UNPACK                         // replace the tuple on top with its values, one for every variable of the DestructuringAssignment Nodes[A]
+	OP_RETURN                         // pop the return value, leave the current frame and push it
+)
+
+var OPCODE_NAMES = map[Opcode]string{
+	OP_CONSTANT:         "CONSTANT",
+	OP_NIL:              "NIL",
+	OP_POP:              "POP",
+	OP_GET_LOCAL:        "GET_LOCAL",
+	OP_SET_LOCAL:        "SET_LOCAL",
+	OP_GET_GLOBAL:       "GET_GLOBAL",
+	OP_SET_GLOBAL:       "SET_GLOBAL",
+	OP_GET_OUTER:        "GET_OUTER",
+	OP_SET_OUTER:        "SET_OUTER",
+	OP_TO_FLOAT:         "TO_FLOAT",
+	OP_UNARY:            "UNARY",
+	OP_BINARY:           "BINARY",
+	OP_COMPARE:          "COMPARE",
+	OP_LOGICAL:          "LOGICAL",
+	OP_INDEX:            "INDEX",
+	OP_CONVERT:          "CONVERT",
+	OP_FROM_ORDINAL:     "FROM_ORDINAL",
+	OP_JUMP:             "JUMP",
+	OP_JUMP_IF_FALSE:    "JUMP_IF_FALSE",
+	OP_JUMP_IF_NOT_NONE: "JUMP_IF_NOT_NONE",
+	OP_JUMP_IF_DECIDED:  "JUMP_IF_DECIDED",
+	OP_MATCH:            "MATCH",
+	OP_LOOP_BOUND:       "LOOP_BOUND",
+	OP_LOOP_TEST:        "LOOP_TEST",
+	OP_INCREMENT:        "INCREMENT",
+	OP_ENTER:            "ENTER",
+	OP_LEAVE:            "LEAVE",
+	OP_CLOSURE:          "CLOSURE",
+	OP_CALL:             "CALL",
+	OP_ARRANGE:          "ARRANGE",
+	OP_CALL_NATIVE:      "CALL_NATIVE",
+	OP_CALL_VALUE:       "CALL_VALUE",
+	OP_LIST:             "LIST",
+	OP_TUPLE:            "TUPLE",
+	OP_UNPACK:           "UNPACK",
+	OP_RETURN:           "RETURN",
+}
+
+type Instruction struct {
+	Op Opcode
+	A  int
+	B  int
+	C  int // how many environments up the variable or function is, for the instructions that say so
+}
+
+// a function compiled to bytecode, the main program is one too
+type CompiledFunction struct {
+	Name   string
+	Type   string // ex - fn(int) -> int
+	Params int    // the arguments are placed in the first slots
+	Slots  int    // number of slots for parameters and variables
+	Code   []Instruction
+}
+
+type Bytecode struct {
+	Main      *CompiledFunction
+	Functions []*CompiledFunction
+	Constants []runtime.Value
+
+	// nodes the instructions refer to, for the operators they apply and the positions errors are reported at
+	Nodes []AbstractSyntaxTree
+}
@@ -1,76 +1,59 @@
 package callstack
 
-import (
-	"programminglang/constants"
-)
+import "programminglang/interpreter/runtime"
 
 /*
-Members = {
-	varName: {
-		varValue: interface{},
-		varType: string,
-	}
-}
+Members = [value of slot 0, value of slot 1, ...]
+
+The resolver gives every variable a slot in the record of the function, loop or program declaring it,
+and the number of records between where it's used and where it's declared (the depth)
 */
 
 type ActivationRecord struct {
 	Name         string
 	Type         string
 	NestingLevel int
-	Members      map[string]map[string]interface{}
-	AboveNode    *ActivationRecord
+	Members      []runtime.Value   // runtime.Nil until the variable is assigned
+	AboveNode    *ActivationRecord // the record of the enclosing function, loop or program
 }
 
 func (ar *ActivationRecord) Init() {
-	ar.Members = map[string]map[string]interface{}{}
+	ar.Members = []runtime.Value{}
 }
 
-func (ar *ActivationRecord) GetActivaionRecordWithKey(key string) *ActivationRecord {
+// the record depth levels above this one, 0 is the record itself
+func (ar *ActivationRecord) Ancestor(depth int) *ActivationRecord {
 	result := ar
 
-	if ar.AboveNode != nil {
-		result = ar.AboveNode.GetActivaionRecordWithKey(key)
+	for ; depth > 0 && result.AboveNode != nil; depth-- {
+		result = result.AboveNode
 	}
 
 	return result
 }
 
-func (ar *ActivationRecord) SetItem(key string, value map[string]interface{}, setVarType bool) {
-
-	arToSet := ar.GetActivaionRecordWithKey(key)
-
-	var (
-		typeToSet  interface{}
-		valueToSet interface{} = value[constants.AR_KEY_VALUE]
-	)
-
-	// helpers.ColorPrint(constants.LightMagenta, 1, 1, constants.SpewPrinter.Sdump(key, value, setVarType))
-
-	// set variable type at var decl, loop counter and function params
-	if setVarType {
-		typeToSet = value[constants.AR_KEY_TYPE]
-	} else {
-		typeToSet = arToSet.Members[key][constants.AR_KEY_TYPE]
-	}
+func (ar *ActivationRecord) SetItem(depth, slot int, value runtime.Value) {
+	arToSet := ar.Ancestor(depth)
 
-	finalValue := map[string]interface{}{
-		constants.AR_KEY_TYPE:  typeToSet,
-		constants.AR_KEY_VALUE: valueToSet,
+	// records grow as their variables are declared
+	for len(arToSet.Members) <= slot {
+		arToSet.Members = append(arToSet.Members, runtime.Nil)
 	}
 
-	// helpers.ColorPrint(constants.LightMagenta, 1, 0, "setting key = ", key, " value = ", finalValue)
+	// helpers.ColorPrint(constants.LightMagenta, 1, 0, "setting slot = ", slot, " value = ", value)
 
-	arToSet.Members[key] = finalValue
+	arToSet.Members[slot] = value
 }
 
-func (ar *ActivationRecord) GetItem(key string) (map[string]interface{}, bool) {
-	value, exists := ar.Members[key]
+// exists is false if the variable hasn't been declared yet in the record
+func (ar *ActivationRecord) GetItem(depth, slot int) (runtime.Value, bool) {
+	arToGet := ar.Ancestor(depth)
 
-	if !exists && ar.AboveNode != nil {
-		value, exists = ar.AboveNode.GetItem(key)
+	if slot >= len(arToGet.Members) {
+		return runtime.Nil, false
 	}
 
-	// helpers.ColorPrint(constants.LightMagenta, 1, 0, "get key = ", key, " value = ", value)
+	// helpers.ColorPrint(constants.LightMagenta, 1, 0, "get slot = ", slot, " value = ", arToGet.Members[slot])
 
-	return value, exists
+	return arToGet.Members[slot], true
 }
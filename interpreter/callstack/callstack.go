@@ -1,20 +1,20 @@
 package callstack
 
 type CallStack struct {
-	Records []ActivationRecord
+	Records []*ActivationRecord
 }
 
-func (cs *CallStack) Push(item ActivationRecord) {
+func (cs *CallStack) Push(item *ActivationRecord) {
 	cs.Records = append(cs.Records, item)
 
 	// helpers.ColorPrint(constants.LightGreen, 1, 1, "Push = ", constants.SpewPrinter.Sdump(cs.Records))
 
 }
 
-func (cs *CallStack) Pop() ActivationRecord {
+func (cs *CallStack) Pop() *ActivationRecord {
 	// helpers.ColorPrint(constants.LightGreen, 1, constants.SpewPrinter.Sdump(cs))
 
-	var poppedItem ActivationRecord
+	var poppedItem *ActivationRecord
 
 	if len(cs.Records) == 0 {
 		return poppedItem
@@ -27,9 +27,9 @@ func (cs *CallStack) Pop() ActivationRecord {
 	return poppedItem
 }
 
-func (cs *CallStack) Peek() (ActivationRecord, bool) {
+func (cs *CallStack) Peek() (*ActivationRecord, bool) {
 	if len(cs.Records) == 0 {
-		return ActivationRecord{}, false
+		return nil, false
 	}
 
 	return cs.Records[len(cs.Records)-1], true
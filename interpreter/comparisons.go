@@ -1,6 +1,9 @@
 package interpreter
 
-import "programminglang/types"
+import (
+	"programminglang/constants"
+	"programminglang/types"
+)
 
 type ComparisonNode struct {
 	Left       AbstractSyntaxTree // left hand of comparison node
@@ -32,4 +35,15 @@ func (cn ComparisonNode) GetRightOperandToken() types.Token {
 	return token
 }
 
-func (fn ComparisonNode) Scope(i *Interpreter) {}
+func (fn ComparisonNode) Scope(i *Interpreter) {
+	fn.Left.Scope(i)
+	fn.Right.Scope(i)
+
+	// optionals can only be checked for being equal, ex - x != none
+	if fn.Comparator.Type != constants.EQUALITY && fn.Comparator.Type != constants.NOT_EQUAL_TO {
+		i.checkNotNone(fn.Left, fn.Comparator)
+		i.checkNotNone(fn.Right, fn.Comparator)
+	}
+
+	i.checkEnumComparison(fn)
+}
@@ -0,0 +1,689 @@
+package interpreter
+
+import (
+	"fmt"
+	"strings"
+
+	"programminglang/constants"
+	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
+)
+
+// what a name refers to while compiling
+type compiledName struct {
+	Slot     int               // the slot of a variable in its environment
+	Function *CompiledFunction // set if the name is a function
+}
+
+type compilerScope struct {
+	Names          map[string]compiledName
+	Function       *CompiledFunction // the function the scope is compiled into
+	Slots          *int              // the slot count of the environment the variables of this scope live in
+	EnclosingScope *compilerScope
+}
+
+type Compiler struct {
+	Interpreter *Interpreter
+	Bytecode    *Bytecode
+	Function    *CompiledFunction // the function being compiled
+	Scope       *compilerScope
+}
+
+/*
+	Compiles a scoped and optimized tree to bytecode for the virtual machine.
+
+	Variables live in slots of the environment of the function declaring them, and the variables of the main
+	program are the globals. Names are resolved the same way the scoping pass does.
+
+	Environments are linked to the one the function was created in, so functions can use the variables around
+	them after the function declaring them has returned. Loops whose body creates functions get a new
+	environment on every iteration, like the records of the tree walker
+*/
+func (i *Interpreter) Compile(tree AbstractSyntaxTree) *Bytecode {
+	main := &CompiledFunction{Name: constants.AR_PROGRAM}
+
+	c := Compiler{
+		Interpreter: i,
+		Bytecode:    &Bytecode{Main: main},
+		Function:    main,
+	}
+
+	c.enterEnvironment(&main.Slots)
+
+	program := tree.(Program)
+	c.declarations(program.Declarations)
+
+	// the value of the last statement is the result of the program, same as the tree walker
+	statements := program.CompoundStatement.(CompoundStatement).Children
+	var last AbstractSyntaxTree
+
+	if len(statements) > 0 && isExpression(statements[len(statements)-1]) {
+		last = statements[len(statements)-1]
+		statements = statements[:len(statements)-1]
+	}
+
+	c.statements(statements)
+
+	if last != nil {
+		c.expression(last)
+	} else {
+		c.emit(OP_NIL, 0, 0)
+	}
+
+	c.emit(OP_RETURN, 0, 0)
+
+	return c.Bytecode
+}
+
+func (c *Compiler) emit(op Opcode, a, b int) int {
+	c.Function.Code = append(c.Function.Code, Instruction{Op: op, A: a, B: b})
+	return len(c.Function.Code) - 1
+}
+
+// points the jump at index to the next instruction to be emitted
+func (c *Compiler) patchJump(index int) {
+	if c.Function.Code[index].Op == OP_LOOP_TEST {
+		c.Function.Code[index].B = len(c.Function.Code)
+	} else {
+		c.Function.Code[index].A = len(c.Function.Code)
+	}
+}
+
+func (c *Compiler) addConstant(value runtime.Value) int {
+	c.Bytecode.Constants = append(c.Bytecode.Constants, value)
+	return len(c.Bytecode.Constants) - 1
+}
+
+func (c *Compiler) addNode(node AbstractSyntaxTree) int {
+	c.Bytecode.Nodes = append(c.Bytecode.Nodes, node)
+	return len(c.Bytecode.Nodes) - 1
+}
+
+func (c *Compiler) emitWithDepth(op Opcode, a, b, depth int) int {
+	index := c.emit(op, a, b)
+	c.Function.Code[index].C = depth
+
+	return index
+}
+
+// a scope whose variables live in the same environment as the enclosing scope's
+func (c *Compiler) enterScope() {
+	c.enterEnvironment(c.Scope.Slots)
+}
+
+// a scope whose variables live in the environment slots counts the slots of
+func (c *Compiler) enterEnvironment(slots *int) {
+	c.Scope = &compilerScope{
+		Names:          map[string]compiledName{},
+		Function:       c.Function,
+		Slots:          slots,
+		EnclosingScope: c.Scope,
+	}
+}
+
+func (c *Compiler) releaseScope() {
+	c.Scope = c.Scope.EnclosingScope
+}
+
+// gives the variable a new slot in the current environment
+func (c *Compiler) defineVariable(name string) int {
+	slot := *c.Scope.Slots
+	*c.Scope.Slots++
+
+	c.Scope.Names[name] = compiledName{Slot: slot}
+
+	return slot
+}
+
+func (c *Compiler) lookup(name string) (compiledName, *compilerScope, bool) {
+	for scope := c.Scope; scope != nil; scope = scope.EnclosingScope {
+		if compiled, ok := scope.Names[name]; ok {
+			return compiled, scope, true
+		}
+	}
+
+	return compiledName{}, nil, false
+}
+
+// the number of environments between the current scope and scope
+func (c *Compiler) depth(scope *compilerScope) int {
+	depth := 0
+
+	for current := c.Scope; current != scope; current = current.EnclosingScope {
+		if current.Slots != current.EnclosingScope.Slots {
+			depth++
+		}
+	}
+
+	return depth
+}
+
+// emits a get or set of the variable, depending on which environment it lives in
+func (c *Compiler) variable(v Variable, get bool) {
+	compiled, scope, exists := c.lookup(v.Value)
+
+	// a declared function used as a value, ex - apply(double, 3)
+	if exists && compiled.Function != nil && get {
+		c.emitWithDepth(OP_CLOSURE, c.functionIndex(compiled.Function), 0, c.depth(scope))
+		return
+	}
+
+	if !exists || compiled.Function != nil {
+		errors.ShowError(
+			constants.SEMANTIC_ERROR,
+			constants.ERROR_VARAIBLE_NOT_DEFINED,
+			fmt.Sprintf("Variable '%s' is not defined.", v.Value),
+			v.Token,
+		)
+	}
+
+	local, global, outer := OP_SET_LOCAL, OP_SET_GLOBAL, OP_SET_OUTER
+
+	if get {
+		local, global, outer = OP_GET_LOCAL, OP_GET_GLOBAL, OP_GET_OUTER
+	}
+
+	depth := c.depth(scope)
+
+	switch {
+	case depth == 0:
+		c.emit(local, compiled.Slot, c.addNode(v))
+
+	case scope.Slots == &c.Bytecode.Main.Slots:
+		c.emit(global, compiled.Slot, c.addNode(v))
+
+	default:
+		c.emitWithDepth(outer, compiled.Slot, c.addNode(v), depth)
+	}
+}
+
+func (c *Compiler) declarations(declarations []AbstractSyntaxTree) {
+	for _, declaration := range declarations {
+		switch d := declaration.(type) {
+		case VariableDeclaration:
+			c.variableDeclaration(d)
+
+		case FunctionDeclaration:
+			c.functionDeclaration(d)
+		}
+	}
+}
+
+func (c *Compiler) variableDeclaration(vd VariableDeclaration) {
+	// the initializer can't refer to the variable being declared
+	if vd.Initializer != nil {
+		c.expression(vd.Initializer)
+
+		if vd.TypeNode != nil && NonOptionalType(vd.TypeNode.GetToken().Value) == constants.FLOAT_TYPE {
+			c.emit(OP_TO_FLOAT, 0, 0)
+		}
+	} else if vd.TypeNode != nil && IsOptionalType(vd.TypeNode.GetToken().Value) {
+		// optionals start out as none
+		c.emit(OP_CONSTANT, c.addConstant(runtime.None), 0)
+	} else {
+		c.emit(OP_NIL, 0, 0)
+	}
+
+	slot := c.defineVariable(vd.VariableNode.GetToken().Value)
+	c.emit(OP_SET_LOCAL, slot, 0)
+}
+
+func (c *Compiler) functionDeclaration(fn FunctionDeclaration) {
+	function := c.newFunction(fn.Symbol)
+
+	// defined before compiling the body so that the function can call itself
+	c.Scope.Names[fn.FunctionName] = compiledName{Function: function}
+
+	c.compileFunction(function, fn.Symbol)
+}
+
+// ex - fn(x: int) -> int { return x * 2; }, enclosed by the environment it's created in
+func (c *Compiler) functionLiteral(fl FunctionLiteral) {
+	function := c.newFunction(fl.Symbol)
+	c.compileFunction(function, fl.Symbol)
+
+	c.emitWithDepth(OP_CLOSURE, c.functionIndex(function), 0, 0)
+}
+
+func (c *Compiler) newFunction(symbol *Symbol) *CompiledFunction {
+	function := &CompiledFunction{
+		Name:   symbol.Name,
+		Type:   symbol.Type,
+		Params: len(symbol.ParamSymbols),
+	}
+
+	c.Bytecode.Functions = append(c.Bytecode.Functions, function)
+
+	return function
+}
+
+// compiles the body of a function from its symbol, which has the body optimized while scoping
+func (c *Compiler) compileFunction(function *CompiledFunction, symbol *Symbol) {
+	enclosingFunction := c.Function
+	c.Function = function
+	c.enterEnvironment(&function.Slots)
+
+	defer func() {
+		c.releaseScope()
+		c.Function = enclosingFunction
+	}()
+
+	for _, param := range symbol.ParamSymbols {
+		c.defineVariable(param.Name)
+	}
+
+	// the returned value is in the function's scope, since it can refer to its variables
+	c.program(symbol.FunctionBlock)
+
+	if symbol.ReturningValue != nil {
+		c.expression(symbol.ReturningValue)
+
+		if NonOptionalType(symbol.ReturnType) == constants.FLOAT_TYPE {
+			c.emit(OP_TO_FLOAT, 0, 0)
+		}
+
+		if IsTupleType(symbol.ReturnType) {
+			for _, position := range floatPositions(symbol.ReturnType) {
+				c.emit(OP_TO_FLOAT, 0, position+1)
+			}
+		}
+	} else {
+		c.emit(OP_NIL, 0, 0)
+	}
+
+	c.emit(OP_RETURN, 0, 0)
+}
+
+// the block of a conditional or loop, which is a Program with its own scope
+func (c *Compiler) block(node AbstractSyntaxTree) {
+	c.enterScope()
+	defer c.releaseScope()
+
+	c.program(node)
+}
+
+// compiles the declarations and statements of a Program in the current scope
+func (c *Compiler) program(node AbstractSyntaxTree) {
+	if program, ok := node.(Program); ok {
+		c.declarations(program.Declarations)
+		c.statement(program.CompoundStatement)
+		return
+	}
+
+	c.statement(node)
+}
+
+func (c *Compiler) statements(statements []AbstractSyntaxTree) {
+	for _, statement := range statements {
+		c.statement(statement)
+	}
+}
+
+func (c *Compiler) statement(node AbstractSyntaxTree) {
+	switch n := node.(type) {
+	case CompoundStatement:
+		for _, child := range n.Children {
+			if child.GetToken().Type == constants.BLANK {
+				continue
+			}
+
+			c.statement(child)
+		}
+
+	case Program:
+		c.block(n)
+
+	case AssignmentStatement:
+		c.expression(n.Right)
+		c.variable(n.Left.(Variable), false)
+
+	// the values are pushed in order, so the last variable is assigned first
+	case DestructuringAssignment:
+		c.expression(n.Right)
+		c.emit(OP_UNPACK, c.addNode(n), 0)
+
+		for index := len(n.Targets) - 1; index >= 0; index-- {
+			c.variable(n.Targets[index].(Variable), false)
+		}
+
+	case ConditionalStatement:
+		c.conditional(n)
+
+	case MatchStatement:
+		c.match(n)
+
+	case RangeLoop:
+		c.rangeLoop(n)
+
+	case BlankStatement, nil:
+
+	default:
+		// expressions used as statements, ex - function calls
+		c.expression(node)
+		c.emit(OP_POP, 0, 0)
+	}
+}
+
+func (c *Compiler) conditional(cs ConditionalStatement) {
+	var jumpsToEnd []int
+
+	for _, branch := range append([]ConditionalStatement{cs}, cs.Ladder...) {
+		if branch.Conditionals == nil {
+			c.block(branch.ConditionalBlock)
+			break
+		}
+
+		c.expression(branch.Conditionals)
+		jumpToNext := c.emit(OP_JUMP_IF_FALSE, 0, 0)
+
+		c.block(branch.ConditionalBlock)
+		jumpsToEnd = append(jumpsToEnd, c.emit(OP_JUMP, 0, 0))
+
+		c.patchJump(jumpToNext)
+	}
+
+	for _, jump := range jumpsToEnd {
+		c.patchJump(jump)
+	}
+}
+
+// the subject is kept in a hidden slot, so it's evaluated once however many arms it's matched against
+func (c *Compiler) match(ms MatchStatement) {
+	c.enterScope()
+	defer c.releaseScope()
+
+	subject := c.defineVariable(" subject")
+
+	c.expression(ms.Subject)
+	c.emit(OP_SET_LOCAL, subject, 0)
+
+	node := c.addNode(ms)
+	var jumpsToEnd []int
+
+	for index, arm := range ms.Arms {
+		c.emit(OP_GET_LOCAL, subject, -1)
+		c.emit(OP_MATCH, node, index)
+
+		jumpsToNext := []int{c.emit(OP_JUMP_IF_FALSE, 0, 0)}
+
+		c.enterScope()
+
+		if binding, ok := arm.Patterns[0].(BindingPattern); ok {
+			c.emit(OP_GET_LOCAL, subject, -1)
+			c.emit(OP_SET_LOCAL, c.defineVariable(binding.Token.Value), 0)
+		}
+
+		if arm.Guard != nil {
+			c.expression(arm.Guard)
+			jumpsToNext = append(jumpsToNext, c.emit(OP_JUMP_IF_FALSE, 0, 0))
+		}
+
+		c.block(arm.Block)
+		c.releaseScope()
+
+		jumpsToEnd = append(jumpsToEnd, c.emit(OP_JUMP, 0, 0))
+
+		for _, jump := range jumpsToNext {
+			c.patchJump(jump)
+		}
+	}
+
+	for _, jump := range jumpsToEnd {
+		c.patchJump(jump)
+	}
+}
+
+/*
+	The counter and the bound are kept in two hidden slots next to each other, and the counter is copied to
+	the iterator on every iteration so assigning to the iterator doesn't change how many times the loop runs
+*/
+func (c *Compiler) rangeLoop(rl RangeLoop) {
+	c.enterScope()
+	defer c.releaseScope()
+
+	counter := c.defineVariable(" counter")
+	bound := c.defineVariable(" bound")
+
+	c.expression(rl.Low)
+	c.emit(OP_LOOP_BOUND, 0, 0)
+	c.emit(OP_SET_LOCAL, counter, 0)
+
+	c.expression(rl.High)
+	c.emit(OP_LOOP_BOUND, 0, 0)
+	c.emit(OP_SET_LOCAL, bound, 0)
+
+	start := len(c.Function.Code)
+	exit := c.emit(OP_LOOP_TEST, counter, 0)
+
+	c.emit(OP_GET_LOCAL, counter, -1)
+
+	// functions created in the body keep the variables of the iteration they were created in
+	slots := 0
+	enter := -1
+
+	if createsFunctions(rl.Block) {
+		enter = c.emit(OP_ENTER, 0, 0)
+		c.enterEnvironment(&slots)
+	} else {
+		c.enterScope()
+	}
+
+	iterator := c.defineVariable(rl.IdentifierToken.Value)
+	c.emit(OP_SET_LOCAL, iterator, 0)
+
+	c.block(rl.Block)
+	c.releaseScope()
+
+	if enter >= 0 {
+		c.Function.Code[enter].A = slots
+		c.emit(OP_LEAVE, 0, 0)
+	}
+
+	c.emit(OP_INCREMENT, counter, 0)
+	c.emit(OP_JUMP, start, 0)
+
+	c.patchJump(exit)
+}
+
+// whether a function is declared or created anywhere in node
+func createsFunctions(node AbstractSyntaxTree) bool {
+	found := false
+
+	WalkTree(node, func(n AbstractSyntaxTree) bool {
+		switch n.(type) {
+		case FunctionDeclaration, FunctionLiteral:
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+func isExpression(node AbstractSyntaxTree) bool {
+	switch node.(type) {
+	case IntegerNumber, FloatNumber, String, Boolean, NoneLiteral, Variable, UnaryOperationNode, BinaryOperationNode,
+		ComparisonNode, LogicalNode, CoalesceNode, ConditionalExpression, FunctionCall, IndexNode, TypeConversion,
+		FunctionLiteral, ListLiteral, TupleLiteral, EnumMember, EnumFromOrdinal:
+		return true
+	}
+
+	return false
+}
+
+// emits the instructions which leave the value of the expression on top of the stack
+func (c *Compiler) expression(node AbstractSyntaxTree) {
+	if value, ok := literalValue(node); ok {
+		c.emit(OP_CONSTANT, c.addConstant(value), 0)
+		return
+	}
+
+	switch n := node.(type) {
+	case Variable:
+		if n.Binding != nil && n.Binding.Constant {
+			c.emit(OP_CONSTANT, c.addConstant(n.Binding.Value), 0)
+			return
+		}
+
+		c.variable(n, true)
+
+	case UnaryOperationNode:
+		c.expression(n.Operand)
+		c.emit(OP_UNARY, c.addNode(n), 0)
+
+	case BinaryOperationNode:
+		c.expression(n.Left)
+		c.expression(n.Right)
+		c.emit(OP_BINARY, c.addNode(n), 0)
+
+	case ComparisonNode:
+		c.expression(n.Left)
+		c.expression(n.Right)
+		c.emit(OP_COMPARE, c.addNode(n), 0)
+
+	// the right operand is only evaluated if the left one doesn't decide the result
+	case LogicalNode:
+		node := c.addNode(n)
+
+		c.expression(n.Left)
+		jumpToEnd := c.emit(OP_JUMP_IF_DECIDED, 0, node)
+
+		c.expression(n.Right)
+		c.emit(OP_LOGICAL, node, 0)
+		c.patchJump(jumpToEnd)
+
+	// the default is only evaluated if the optional is none
+	case CoalesceNode:
+		c.expression(n.Left)
+		jumpToEnd := c.emit(OP_JUMP_IF_NOT_NONE, 0, 0)
+
+		c.expression(n.Right)
+		c.patchJump(jumpToEnd)
+
+	// only the branch the condition chooses is evaluated
+	case ConditionalExpression:
+		c.expression(n.Condition)
+		jumpToFalse := c.emit(OP_JUMP_IF_FALSE, 0, 0)
+
+		c.branch(n.WhenTrue, n.Binding.Type)
+		jumpToEnd := c.emit(OP_JUMP, 0, 0)
+
+		c.patchJump(jumpToFalse)
+		c.branch(n.WhenFalse, n.Binding.Type)
+		c.patchJump(jumpToEnd)
+
+	case IndexNode:
+		c.expression(n.Left)
+		c.expression(n.Index)
+		c.emit(OP_INDEX, c.addNode(n), 0)
+
+	case TypeConversion:
+		c.expression(n.Argument)
+		c.emit(OP_CONVERT, c.addNode(n), 0)
+
+	case EnumFromOrdinal:
+		c.expression(n.Argument)
+		c.emit(OP_FROM_ORDINAL, c.addNode(n), 0)
+
+	case FunctionCall:
+		c.functionCall(n)
+
+	case FunctionLiteral:
+		c.functionLiteral(n)
+
+	case ListLiteral:
+		for _, element := range n.Elements {
+			c.expression(element)
+
+			if n.holdsFloats() {
+				c.emit(OP_TO_FLOAT, 0, 0)
+			}
+		}
+
+		c.emit(OP_LIST, len(n.Elements), 0)
+
+	case TupleLiteral:
+		for _, element := range n.Elements {
+			c.expression(element)
+		}
+
+		c.emit(OP_TUPLE, len(n.Elements), 0)
+
+	default:
+		errors.ShowError(
+			constants.SEMANTIC_ERROR,
+			constants.ERROR_UNSUPPORTED,
+			fmt.Sprintf("The vm engine cannot compile %T", node),
+			node.GetToken(),
+		)
+	}
+}
+
+// a branch of a conditional expression, an int chosen over a float is a float
+func (c *Compiler) branch(node AbstractSyntaxTree, branchType string) {
+	c.expression(node)
+
+	if branchType == constants.FLOAT_TYPE {
+		c.emit(OP_TO_FLOAT, 0, 0)
+	}
+}
+
+func (c *Compiler) functionCall(f FunctionCall) {
+	// the arguments were checked against the parameters while scoping
+	paramTypes, _ := FunctionTypeParts(f.Binding.Type)
+
+	for index, param := range f.ActualParameters {
+		c.expression(param)
+
+		// ints can be passed for float parameters, and in the list of a variadic one
+		if paramIndex := f.Binding.ParameterOf(index); paramIndex < len(paramTypes) &&
+			NonOptionalType(strings.TrimPrefix(paramTypes[paramIndex], constants.ELLIPSIS_SYMBOL)) == constants.FLOAT_TYPE {
+			c.emit(OP_TO_FLOAT, 0, 0)
+		}
+	}
+
+	compiled, scope, exists := c.lookup(f.FunctionName)
+	argCount := len(f.ActualParameters)
+
+	// named arguments, defaults or the arguments of a variadic parameter
+	if exists && f.Binding.Arguments != nil {
+		c.emit(OP_ARRANGE, c.addNode(f), argCount)
+		argCount = len(f.Binding.Arguments)
+	}
+
+	// the arguments were arranged, or checked while scoping, to be one for every parameter
+	if exists && compiled.Function != nil {
+		c.emitWithDepth(OP_CALL, c.functionIndex(compiled.Function), c.addNode(f), c.depth(scope))
+		return
+	}
+
+	if _, isNative := NativeFunctions[f.FunctionName]; isNative && !exists {
+		c.emit(OP_CALL_NATIVE, c.addNode(f), 0)
+		return
+	}
+
+	// a parameter or variable holding a function, which is pushed after the arguments
+	if exists {
+		c.variable(Variable{Token: f.Token, Value: f.FunctionName, Binding: f.Binding}, true)
+		c.emit(OP_CALL_VALUE, c.addNode(f), argCount)
+		return
+	}
+
+	errors.ShowError(
+		constants.SEMANTIC_ERROR,
+		constants.ERROR_VARAIBLE_NOT_DEFINED,
+		fmt.Sprintf("Function %s is not defined", f.FunctionName),
+		f.Token,
+	)
+}
+
+func (c *Compiler) functionIndex(function *CompiledFunction) int {
+	for index, compiled := range c.Bytecode.Functions {
+		if compiled == function {
+			return index
+		}
+	}
+
+	return -1
+}
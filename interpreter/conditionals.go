@@ -1,6 +1,13 @@
 package interpreter
 
-import "programminglang/types"
+import (
+	"fmt"
+
+	"programminglang/constants"
+	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
+	"programminglang/types"
+)
 
 type ConditionalStatement struct {
 	Type             string // if, elif, else
@@ -10,8 +17,175 @@ type ConditionalStatement struct {
 	Ladder           []ConditionalStatement // for if-elif-else ladder
 }
 
+// the value of one of two expressions depending on a condition. Ex - if x > 0 then x else -x and x > 0 ? x : -x
+type ConditionalExpression struct {
+	Condition AbstractSyntaxTree
+	Token     types.Token // the IF or QUESTION token
+	WhenTrue  AbstractSyntaxTree
+	WhenFalse AbstractSyntaxTree
+	Binding   *Binding // the type of both branches, found while scoping
+}
+
 func (cs ConditionalStatement) GetToken() types.Token {
 	return cs.Token
 }
 
-func (cs ConditionalStatement) Scope(_ *Interpreter) {}
+/*
+	Every branch starts from what was assigned before the conditional, and what's assigned after it is what
+	every branch assigns.
+
+	Optional variables the conditions prove aren't none are narrowed to their type without the ? in the
+	branches they hold in, ex - x in if x != none { } and in the else of if x == none { }
+*/
+func (cs ConditionalStatement) Scope(i *Interpreter) {
+	before := i.Assignments.snapshot()
+	var branches []map[variableKey]bool
+
+	// the optionals proven not to be none by the conditions before the current one being false
+	var narrowedByPrevious []Symbol
+	hasElse := false
+
+	for _, branch := range append([]ConditionalStatement{cs}, cs.Ladder...) {
+		// the elif conditions run only when the previous conditions were false
+		i.Assignments.restore(before)
+
+		narrowed := append([]Symbol{}, narrowedByPrevious...)
+
+		// else blocks don't have a condition
+		if branch.Conditionals != nil {
+			i.EnterScope(branch.Type)
+			i.narrow(narrowedByPrevious, branch.Conditionals)
+
+			branch.Conditionals.Scope(i)
+			i.checkNotNone(branch.Conditionals, branch.Token)
+
+			narrowed = append(narrowed, i.narrowedBy(branch.Conditionals, true)...)
+			narrowedByPrevious = append(narrowedByPrevious, i.narrowedBy(branch.Conditionals, false)...)
+
+			i.ReleaseScope()
+		} else {
+			hasElse = true
+		}
+
+		// the narrowed variables get a scope of their own, so the block can still declare variables of the same name
+		i.EnterScope(branch.Type)
+		i.narrow(narrowed, branch.ConditionalBlock)
+
+		i.EnterScope(branch.Type)
+		branch.ConditionalBlock.Scope(i)
+
+		i.ReleaseScope()
+		i.ReleaseScope()
+
+		branches = append(branches, i.Assignments.snapshot())
+	}
+
+	// without an else, none of the blocks might run
+	if !hasElse {
+		branches = append(branches, before)
+	}
+
+	i.Assignments.restore(intersectAssignments(branches))
+}
+
+func (ce ConditionalExpression) GetToken() types.Token {
+	return ce.Token
+}
+
+/*
+	The branches are narrowed by the condition like the blocks of a conditional statement, and have to have a
+	type both of them can be stored in. Ex - an int and a float make a float, a str and none a str?
+*/
+func (ce ConditionalExpression) Scope(i *Interpreter) {
+	ce.Condition.Scope(i)
+	i.checkNotNone(ce.Condition, ce.Token)
+
+	if conditionType := i.TypeOf(ce.Condition); conditionType != "" && conditionType != constants.BOOLEAN_TYPE {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_TYPE_MISMATCH,
+			fmt.Sprintf("The condition of a conditional expression has to be a bool, got %s", conditionType),
+			ce.Token,
+		)
+	}
+
+	scopeBranch := func(branch AbstractSyntaxTree, whenTrue bool) {
+		i.EnterScope(ce.Token.Value)
+		i.narrow(i.narrowedBy(ce.Condition, whenTrue), branch)
+
+		branch.Scope(i)
+
+		i.ReleaseScope()
+	}
+
+	scopeBranch(ce.WhenTrue, true)
+	scopeBranch(ce.WhenFalse, false)
+
+	trueType, falseType := i.TypeOf(ce.WhenTrue), i.TypeOf(ce.WhenFalse)
+	unified, ok := unifiedType(trueType, falseType)
+
+	if !ok {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_TYPE_MISMATCH,
+			fmt.Sprintf("The branches of a conditional expression have to have the same type, got %s and %s", trueType, falseType),
+			ce.Token,
+		)
+	}
+
+	ce.Binding.Type = unified
+}
+
+// only the branch the condition chooses is evaluated
+func (i *Interpreter) EvaluateConditionalExpression(ce ConditionalExpression) runtime.Value {
+	branch := ce.WhenFalse
+
+	if i.Visit(ce.Condition).Bool {
+		branch = ce.WhenTrue
+	}
+
+	value := i.Visit(branch)
+
+	// an int chosen over a float is a float, ex - c ? 1 : 2.5
+	if value.Kind == runtime.INT && ce.Binding.Type == constants.FLOAT_TYPE {
+		value = runtime.FloatValue(float32(value.Int))
+	}
+
+	return value
+}
+
+/*
+	The type values of both types can be stored in, ex - int and float make float, int and none make int?.
+
+	Returns false if there's no such type
+*/
+func unifiedType(first, second string) (string, bool) {
+	if first == "" || second == "" {
+		return "", true
+	}
+
+	if IsAssignable(first, second) {
+		return first, true
+	}
+
+	if IsAssignable(second, first) {
+		return second, true
+	}
+
+	// one of them might be none, so the result is optional. Ex - int? and float make float?
+	if MightBeNone(first) || MightBeNone(second) {
+		if first == constants.NONE_TYPE {
+			return OptionalTypeOf(second), true
+		}
+
+		if second == constants.NONE_TYPE {
+			return OptionalTypeOf(first), true
+		}
+
+		if unified, ok := unifiedType(NonOptionalType(first), NonOptionalType(second)); ok && unified != "" {
+			return OptionalTypeOf(unified), true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,145 @@
+package interpreter
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"programminglang/constants"
+	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
+	"programminglang/types"
+)
+
+// explicit conversion to a built in type. Ex - int("42"), str(3.5)
+type TypeConversion struct {
+	Token    types.Token // the type keyword, ex - int
+	Argument AbstractSyntaxTree
+}
+
+func (tc TypeConversion) GetToken() types.Token {
+	return tc.Token
+}
+
+func (tc TypeConversion) Scope(i *Interpreter) {
+	tc.Argument.Scope(i)
+	i.checkNotNone(tc.Argument, tc.Token)
+
+	argumentType := i.TypeOf(tc.Argument)
+
+	// the ordinal of an enum member is got with ordinal(), ex - ordinal(Color.Red)
+	if i.isEnumType(argumentType) && tc.Token.Value != constants.STRING_TYPE {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.TYPE_ERROR,
+			fmt.Sprintf("Cannot convert %s to %s, use %s() for its ordinal", argumentType, tc.Token.Value, constants.ORDINAL),
+			tc.Token,
+		)
+	}
+
+	// lists can only be turned into strings
+	if IsListType(argumentType) && tc.Token.Value != constants.STRING_TYPE {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.TYPE_ERROR,
+			fmt.Sprintf("Cannot convert %s to %s", argumentType, tc.Token.Value),
+			tc.Token,
+		)
+	}
+}
+
+func (i *Interpreter) EvaluateTypeConversion(tc TypeConversion) runtime.Value {
+	return convert(tc, i.Visit(tc.Argument))
+}
+
+// converts the evaluated argument of tc
+func convert(tc TypeConversion, value runtime.Value) runtime.Value {
+	targetType := tc.Token.Value
+
+	conversionError := func(reason string) {
+		errors.ShowError(
+			constants.RUNTIME_ERROR,
+			constants.ERROR_CONVERSION,
+			fmt.Sprintf("Cannot convert '%v' to %s%s", value, targetType, reason),
+			tc.Token,
+		)
+	}
+
+	switch targetType {
+	case constants.STRING_TYPE:
+		// same as what output() prints
+		return runtime.StringValue(value.String())
+
+	case constants.INTEGER_TYPE:
+		switch value.Kind {
+		case runtime.INT:
+			return value
+		case runtime.FLOAT:
+			// nan, inf and the floats past the range of int have no int to truncate to
+			if number := float64(value.Float); math.IsNaN(number) || number < math.MinInt64 || number >= -math.MinInt64 {
+				conversionError(", out of the range of int")
+			}
+
+			// truncates towards zero
+			return runtime.IntValue(int(value.Float))
+		case runtime.BOOL:
+			if value.Bool {
+				return runtime.IntValue(1)
+			}
+			return runtime.IntValue(0)
+		case runtime.STRING:
+			result, err := strconv.Atoi(strings.TrimSpace(value.Str))
+
+			if err != nil {
+				conversionError(", not a valid integer")
+			}
+
+			return runtime.IntValue(result)
+		}
+
+	case constants.FLOAT_TYPE:
+		switch value.Kind {
+		case runtime.INT:
+			return runtime.FloatValue(float32(value.Int))
+		case runtime.FLOAT:
+			return value
+		case runtime.BOOL:
+			if value.Bool {
+				return runtime.FloatValue(1)
+			}
+			return runtime.FloatValue(0)
+		case runtime.STRING:
+			result, err := strconv.ParseFloat(strings.TrimSpace(value.Str), 32)
+
+			if err != nil {
+				conversionError(", not a valid float")
+			}
+
+			return runtime.FloatValue(float32(result))
+		}
+
+	case constants.BOOLEAN_TYPE:
+		switch value.Kind {
+		case runtime.INT:
+			return runtime.BoolValue(value.Int != 0)
+		case runtime.FLOAT:
+			return runtime.BoolValue(value.Float != 0)
+		case runtime.BOOL:
+			return value
+		case runtime.STRING:
+			// accepts true, false, 1, 0, t, f and their upper case variants
+			result, err := strconv.ParseBool(strings.TrimSpace(value.Str))
+
+			if err != nil {
+				conversionError(", not a valid boolean")
+			}
+
+			return runtime.BoolValue(result)
+		}
+	}
+
+	conversionError("")
+
+	return runtime.Nil
+}
@@ -0,0 +1,245 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"programminglang/constants"
+	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
+	"programminglang/types"
+)
+
+// a type with a fixed set of named values, ex - enum Color { Red, Green, Blue }
+type EnumDeclaration struct {
+	Token   types.Token   // the name of the enum
+	Members []types.Token // the names of the members, in the order of their ordinals
+	Enum    *runtime.Enum // shared by all the values of the enum
+}
+
+// one of the values of an enum, ex - Color.Red
+type EnumMember struct {
+	Token   types.Token // the name of the enum
+	Member  types.Token
+	Binding *Binding // the member's value, found while scoping
+}
+
+// the member of an enum with an ordinal, ex - Color.from_ordinal(2)
+type EnumFromOrdinal struct {
+	Token    types.Token // the name of the enum
+	Argument AbstractSyntaxTree
+	Binding  *Binding // the enum, found while scoping
+}
+
+func (ed EnumDeclaration) GetToken() types.Token {
+	return ed.Token
+}
+
+func (ed EnumDeclaration) Scope(i *Interpreter) {
+	name := ed.Token.Value
+
+	// built in functions and constants can be shadowed
+	if symbol, exists := i.CurrentScope.LookupSymbol(name, true); exists && !symbol.BuiltIn {
+		i.CurrentScope.Error(constants.ERROR_DUPLICATE_ID, ed.Token)
+	}
+
+	for index, member := range ed.Members {
+		for _, previous := range ed.Members[:index] {
+			if previous.Value == member.Value {
+				errors.ShowError(
+					constants.SEMANTIC_ERROR,
+					constants.ERROR_DUPLICATE_ID,
+					fmt.Sprintf("'%s' is a member of %s more than once", member.Value, name),
+					member,
+				)
+			}
+		}
+
+		// Color.from_ordinal(n) would be ambiguous
+		if member.Value == constants.FROM_ORDINAL {
+			errors.ShowError(
+				constants.SEMANTIC_ERROR,
+				constants.ERROR_UNSUPPORTED,
+				fmt.Sprintf("An enum member cannot be named '%s'", constants.FROM_ORDINAL),
+				member,
+			)
+		}
+	}
+
+	i.CurrentScope.DefineSymbol(Symbol{
+		Name: name,
+		Type: constants.ENUM_TYPE,
+		Enum: ed.Enum,
+	})
+}
+
+// the enum named by token, errors if there's no such enum
+func (i *Interpreter) lookupEnum(token types.Token) *runtime.Enum {
+	symbol, exists := i.CurrentScope.LookupSymbol(token.Value, false)
+
+	if !exists {
+		i.CurrentScope.Error(constants.ERROR_ID_NOT_FOUND, token)
+	}
+
+	if symbol.Type != constants.ENUM_TYPE {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_TYPE_MISMATCH,
+			fmt.Sprintf("'%s' isn't an enum, it has no members", token.Value),
+			token,
+		)
+	}
+
+	return symbol.Enum
+}
+
+func (em EnumMember) GetToken() types.Token {
+	return em.Token
+}
+
+// the members are constants, so their uses are folded into their values
+func (em EnumMember) Scope(i *Interpreter) {
+	enum := i.lookupEnum(em.Token)
+
+	for ordinal, member := range enum.Members {
+		if member == em.Member.Value {
+			em.Binding.Type = enum.Name
+			em.Binding.Constant = true
+			em.Binding.Value = runtime.EnumValue(enum, ordinal)
+
+			return
+		}
+	}
+
+	errors.ShowError(
+		constants.SEMANTIC_ERROR,
+		constants.ERROR_ID_NOT_FOUND,
+		fmt.Sprintf("%s has no member '%s'", enum.Name, em.Member.Value),
+		em.Member,
+	)
+}
+
+func (efo EnumFromOrdinal) GetToken() types.Token {
+	return efo.Token
+}
+
+func (efo EnumFromOrdinal) Scope(i *Interpreter) {
+	enum := i.lookupEnum(efo.Token)
+
+	efo.Argument.Scope(i)
+	i.checkNotNone(efo.Argument, efo.Token)
+
+	if argumentType := i.TypeOf(efo.Argument); argumentType != "" && argumentType != constants.INTEGER_TYPE {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_TYPE_MISMATCH,
+			fmt.Sprintf("%s.%s expects an int, got %s", enum.Name, constants.FROM_ORDINAL, argumentType),
+			efo.Token,
+		)
+	}
+
+	efo.Binding.Type = enum.Name
+	efo.Binding.Enum = enum
+}
+
+func (i *Interpreter) EvaluateEnumFromOrdinal(efo EnumFromOrdinal) runtime.Value {
+	return fromOrdinal(efo, i.Visit(efo.Argument))
+}
+
+// the member of the enum of efo with the evaluated ordinal
+func fromOrdinal(efo EnumFromOrdinal, ordinal runtime.Value) runtime.Value {
+	enum := efo.Binding.Enum
+
+	if ordinal.Int < 0 || ordinal.Int >= len(enum.Members) {
+		errors.ShowError(
+			constants.RUNTIME_ERROR,
+			constants.ERROR_INDEX_OUT_OF_RANGE,
+			fmt.Sprintf("%s has no member with ordinal %d", enum.Name, ordinal.Int),
+			efo.Token,
+		)
+	}
+
+	return runtime.EnumValue(enum, ordinal.Int)
+}
+
+// whether the type is the name of an enum, ex - Color
+func (i *Interpreter) isEnumType(typeName string) bool {
+	symbol, exists := i.CurrentScope.LookupSymbol(typeName, false)
+
+	return exists && symbol.Type == constants.ENUM_TYPE
+}
+
+/*
+	Enums can only be compared for equality, with members of the same enum. Ex - Color.Red == Color.Blue,
+	but not Color.Red < Color.Blue or Color.Red == Size.Small
+*/
+func (i *Interpreter) checkEnumComparison(cn ComparisonNode) {
+	leftType, rightType := i.TypeOf(cn.Left), i.TypeOf(cn.Right)
+
+	if !i.isEnumType(NonOptionalType(leftType)) && !i.isEnumType(NonOptionalType(rightType)) {
+		return
+	}
+
+	if cn.Comparator.Type != constants.EQUALITY && cn.Comparator.Type != constants.NOT_EQUAL_TO {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.TYPE_ERROR,
+			fmt.Sprintf("Enums can only be compared with == and !=, compare their ordinal() instead of using '%s'", cn.Comparator.Value),
+			cn.Comparator,
+		)
+	}
+
+	if leftType != "" && rightType != "" && !MightBeNone(leftType) && !MightBeNone(rightType) && leftType != rightType {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.TYPE_ERROR,
+			fmt.Sprintf("Cannot compare a value of type %s with a value of type %s", leftType, rightType),
+			cn.Comparator,
+		)
+	}
+}
+
+// enums don't support arithmetic, ex - Color.Red + 1
+func (i *Interpreter) checkNotEnum(node AbstractSyntaxTree, token types.Token) {
+	if valueType := i.TypeOf(node); i.isEnumType(valueType) {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.TYPE_ERROR,
+			fmt.Sprintf("Operand '%s' not defined for type %s", token.Value, valueType),
+			token,
+		)
+	}
+}
+
+func init() {
+	RegisterNativeFunction(NativeFunction{
+		Name:       constants.ORDINAL,
+		ReturnType: constants.INTEGER_TYPE,
+		Call:       nativeOrdinal,
+		Check:      checkOrdinalCall,
+	})
+}
+
+// ordinal(member) is the position of the member in its enum, starting from 0
+func nativeOrdinal(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	return runtime.IntValue(args[0].Int)
+}
+
+func checkOrdinalCall(i *Interpreter, f FunctionCall, argTypes []string) {
+	if len(argTypes) != 1 {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_INVALID_ARGUMENT,
+			fmt.Sprintf("%s expects a member of an enum", f.FunctionName),
+			f.Token,
+		)
+	}
+
+	if argTypes[0] != "" && !i.isEnumType(argTypes[0]) {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_INVALID_ARGUMENT,
+			fmt.Sprintf("%s expects a member of an enum, got %s", f.FunctionName, argTypes[0]),
+			f.Token,
+		)
+	}
+}
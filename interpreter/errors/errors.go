@@ -2,14 +2,14 @@ package errors
 
 import (
 	"fmt"
-	"os"
 	"programminglang/constants"
-	"programminglang/helpers"
 	"programminglang/types"
 )
 
+// the errors of the language, Go's runtime errors are also errors but aren't reported as if the script raised them
 type ErrorInterface interface {
-	PrintError()
+	error
+	languageError()
 }
 
 // Errors found in LexicalAnalyzer
@@ -45,31 +45,32 @@ type TypeError struct {
 	Message   string
 }
 
-func (lxe *LexerError) PrintError() {
-	helpers.ColorPrint(constants.Red, 1, 1, fmt.Sprintf("LexerError: %s. %s", lxe.Message, lxe.Token.PrintLineCol()))
-	os.Exit(1)
+func (lxe *LexerError) Error() string {
+	return fmt.Sprintf("LexerError: %s. %s", lxe.Message, lxe.Token.PrintLineCol())
 }
 
-func (pe *ParseError) PrintError() {
-	helpers.ColorPrint(constants.Red, 1, 1, fmt.Sprintf("ParseError: %s. %s", pe.Message, pe.Token.PrintLineCol()))
-	os.Exit(1)
+func (pe *ParseError) Error() string {
+	return fmt.Sprintf("ParseError: %s. %s", pe.Message, pe.Token.PrintLineCol())
 }
 
-func (se *SemanticError) PrintError() {
-	helpers.ColorPrint(constants.Red, 1, 1, fmt.Sprintf("SemanticError: %s. %s", se.Message, se.Token.PrintLineCol()))
-	os.Exit(1)
+func (se *SemanticError) Error() string {
+	return fmt.Sprintf("SemanticError: %s. %s", se.Message, se.Token.PrintLineCol())
 }
 
-func (re *RuntimeError) PrintError() {
-	helpers.ColorPrint(constants.Red, 1, 1, fmt.Sprintf("RuntimeError: %s. %s", re.Message, re.Token.PrintLineCol()))
-	os.Exit(1)
+func (re *RuntimeError) Error() string {
+	return fmt.Sprintf("RuntimeError: %s. %s", re.Message, re.Token.PrintLineCol())
 }
 
-func (te *TypeError) PrintError() {
-	helpers.ColorPrint(constants.Red, 1, 1, fmt.Sprintf("TypeError: %s. %s", te.Message, te.Token.PrintLineCol()))
-	os.Exit(1)
+func (te *TypeError) Error() string {
+	return fmt.Sprintf("TypeError: %s. %s", te.Message, te.Token.PrintLineCol())
 }
 
+func (lxe *LexerError) languageError()   {}
+func (pe *ParseError) languageError()    {}
+func (se *SemanticError) languageError() {}
+func (re *RuntimeError) languageError()  {}
+func (te *TypeError) languageError()     {}
+
 func ShowError(errorType string, errorCode string, message string, token types.Token) {
 	var e ErrorInterface
 
@@ -105,5 +106,6 @@ func ShowError(errorType string, errorCode string, message string, token types.T
 		}
 	}
 
-	e.PrintError()
+	// unwinds all the way up to Interpreter.Interpret which reports the error
+	panic(e)
 }
@@ -5,31 +5,45 @@ import (
 	"math"
 
 	"programminglang/constants"
-	"programminglang/helpers"
 	"programminglang/interpreter/callstack"
 	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
 )
 
-func (i *Interpreter) EvaluateInteger(node IntegerNumber) interface{} {
-	return node.Token.IntegerValue
+func (i *Interpreter) EvaluateInteger(node IntegerNumber) runtime.Value {
+	return runtime.IntValue(node.Token.IntegerValue)
 }
 
-func (i *Interpreter) EvaluateUnaryOperator(node UnaryOperationNode) interface{} {
-	var result interface{}
+func (i *Interpreter) EvaluateUnaryOperator(node UnaryOperationNode) runtime.Value {
+	return unaryOperation(node, i.Visit(node.Operand))
+}
+
+// applies the unary operator of node to the evaluated operand
+func unaryOperation(node UnaryOperationNode, operand runtime.Value) runtime.Value {
+	var result runtime.Value
 
-	result1, _ := helpers.GetFloat(i.Visit(node.Operand))
+	// integers stay integers
+	if operand.Kind == runtime.INT {
+		if node.Operation.Type == constants.MINUS {
+			return runtime.IntValue(-operand.Int)
+		}
+
+		return operand
+	}
+
+	result1 := operand.Number()
 
 	if node.Operation.Type == constants.PLUS {
-		result = +result1
+		result = runtime.FloatValue(+result1)
 	} else if node.Operation.Type == constants.MINUS {
-		result = -result1
+		result = runtime.FloatValue(-result1)
 	}
 
 	return result
 }
 
-func (i *Interpreter) EvaluateProgram(p Program) interface{} {
-	var result interface{}
+func (i *Interpreter) EvaluateProgram(p Program) runtime.Value {
+	var result runtime.Value
 
 	// fmt.Println("Enter program")
 
@@ -40,7 +54,7 @@ func (i *Interpreter) EvaluateProgram(p Program) interface{} {
 	if !exists {
 		nl := 1
 
-		ar := callstack.ActivationRecord{
+		ar := &callstack.ActivationRecord{
 			Name:         constants.AR_PROGRAM,
 			Type:         constants.AR_PROGRAM,
 			NestingLevel: nl,
@@ -57,81 +71,93 @@ func (i *Interpreter) EvaluateProgram(p Program) interface{} {
 	result = i.Visit(p.CompoundStatement)
 
 	// fmt.Println("Leave program")
-	if topAr, ok := i.CallStack.Peek(); ok {
-		if topAr.Name == constants.AR_PROGRAM {
-			i.CallStack.Pop()
-		}
+	// only pop the record pushed above, blocks of ifs and loops are programs too
+	if !exists {
+		i.CallStack.Pop()
 	}
 
 	return result
 }
 
-func (i *Interpreter) EvaluateFunctionCall(f FunctionCall) interface{} {
-	var result interface{}
-
+func (i *Interpreter) EvaluateFunctionCall(f FunctionCall) runtime.Value {
 	// helpers.ColorPrint(constants.LightGreen, 1, 1, constants.SpewPrinter.Sdump(i.CallStack))
 
-	functionName := f.FunctionName
+	var args []runtime.Value
+
+	for _, param := range f.ActualParameters {
+		args = append(args, i.Visit(param))
+	}
+
+	// built in functions are implemented in Go, hand them the evaluated arguments
+	if f.Binding.Native != nil {
+		return f.Binding.Native.Call(i, f, args)
+	}
 
 	topAr, _ := i.CallStack.Peek()
 
-	actualParams := f.ActualParameters
+	// declared functions are stored in slots like variables, so this is the same for parameters holding functions
+	function, _ := topAr.GetItem(f.Binding.Depth, f.Binding.Slot)
 
-	/*
-		1. Get a list of the function's formal parameters
-		2. Get a list of the function's actual parameters (arguments)
-		3. For each formal parameter, get the corresponding actual parameter and save the pair in the function's activation record by using the formal parameter’s name as a key and the actual parameter (argument), after having evaluated it, as the value
-	*/
+	if function.IsNil() {
+		usedBeforeAssignment(Variable{Token: f.Token, Value: f.FunctionName})
+	}
 
-	funcSymbol, _ := i.CurrentScope.LookupSymbol(functionName, false)
-	formalParams := funcSymbol.ParamSymbols
+	// the number and types of the arguments are checked while scoping
+	return i.callFunction(f, function.Function.Implementation.(*Closure), f.Binding.ArrangeArguments(args))
+}
 
-	// helpers.ColorPrint(constants.LightCyan, 1, 1, constants.SpewPrinter.Sdump(funcSymbol))
+// the same error in both engines, instead of the tree walker running out of Go stack and the vm out of memory
+func callDepthExceeded(f FunctionCall) {
+	errors.ShowError(
+		constants.RUNTIME_ERROR,
+		constants.ERROR_CALL_DEPTH,
+		fmt.Sprintf("Maximum call depth of %d exceeded calling %s", constants.MAX_CALL_DEPTH, f.FunctionName),
+		f.Token,
+	)
+}
 
-	// print to stdout if it's a print function
-	if functionName == constants.PRINT_OUTPUT {
-		for index := range actualParams {
-			param := actualParams[index]
+func (i *Interpreter) callFunction(f FunctionCall, closure *Closure, args []runtime.Value) runtime.Value {
+	var result runtime.Value
 
-			color := constants.LightYellow
+	if i.callDepth == constants.MAX_CALL_DEPTH {
+		callDepthExceeded(f)
+	}
 
-			if _, ok := param.(ComparisonNode); ok {
-				color = constants.LightCyan
-			}
+	i.callDepth++
 
-			if _, ok := param.(String); ok {
-				color = constants.LightGreen
-			}
+	funcSymbol := closure.Symbol
 
-			helpers.ColorPrint(color, 0, 0, i.Visit(param))
-		}
-		fmt.Println()
-		return result
-	}
+	/*
+		1. Get a list of the function's formal parameters
+		2. Get a list of the function's actual parameters (arguments), already evaluated
+		3. For each formal parameter, save the corresponding argument in the slot of the parameter in the function's activation record
+	*/
+
+	formalParams := funcSymbol.ParamSymbols
 
-	ar := callstack.ActivationRecord{
-		Name:         functionName,
+	// the record above is the one the function was created in, not the caller's
+	ar := &callstack.ActivationRecord{
+		Name:         funcSymbol.Name,
 		Type:         constants.AR_FUNCTION,
-		NestingLevel: topAr.NestingLevel + 1,
-		AboveNode:    &topAr,
+		NestingLevel: closure.Record.NestingLevel + 1,
+		AboveNode:    closure.Record,
 	}
 	ar.Init()
 
 	// helpers.ColorPrint(constants.LightCyan, 1, 1, "funcsymbol = ", constants.SpewPrinter.Sdump(funcSymbol))
-	// helpers.ColorPrint(constants.Magenta, 1, 1, "CurrentScopename = ", i.CurrentScope.CurrentScopeName)
 	// helpers.ColorPrint(constants.Magenta, 1, 1, "Formal Params = ", constants.SpewPrinter.Sdump(formalParams))
-	// helpers.ColorPrint(constants.Cyan, 1, 1, "Actual Params = ", constants.SpewPrinter.Sdump(actualParams))/
 
-	for index := range formalParams {
-		fp := formalParams[index]
-		ap := actualParams[index]
+	for index, param := range formalParams {
+		arg := args[index]
 
-		value := map[string]interface{}{
-			constants.AR_KEY_TYPE:  fp.Type,
-			constants.AR_KEY_VALUE: i.Visit(ap),
+		// ints can be passed for float parameters, and in the list of a variadic one
+		if arg.Kind == runtime.INT && NonOptionalType(param.Type) == constants.FLOAT_TYPE {
+			arg = runtime.FloatValue(float32(arg.Int))
+		} else if param.Variadic && param.Type == ListTypeOf(constants.FLOAT_TYPE) {
+			arg = toFloats(arg)
 		}
 
-		ar.SetItem(fp.Name, value, true)
+		ar.SetItem(0, param.Slot, arg)
 	}
 
 	// helpers.ColorPrint(
@@ -148,40 +174,57 @@ func (i *Interpreter) EvaluateFunctionCall(f FunctionCall) interface{} {
 		result = i.Visit(funcSymbol.ReturningValue)
 	}
 
+	// define double(x: int) -> float { return x * 2; }
+	if result.Kind == runtime.INT && NonOptionalType(funcSymbol.ReturnType) == constants.FLOAT_TYPE {
+		result = runtime.FloatValue(float32(result.Int))
+	}
+
+	// define split(n: int) -> (int, float) { return n, n; }
+	if IsTupleType(funcSymbol.ReturnType) {
+		for _, position := range floatPositions(funcSymbol.ReturnType) {
+			result = tupleToFloat(result, position)
+		}
+	}
+
 	// helpers.ColorPrint(constants.LightGreen, 1, 1, constants.SpewPrinter.Sdump(i.CallStack))
 	// helpers.ColorPrint(constants.Green, 1, 1, "returning from function ", result)
 
 	// pop the ActivationRecord at the top of the call stack after function execution is done
 	i.CallStack.Pop()
+	i.callDepth--
 
 	return result
 }
 
-func (i *Interpreter) EvaluateVariableDeclaration(vd VariableDeclaration) interface{} {
-	var result interface{}
+func (i *Interpreter) EvaluateVariableDeclaration(vd VariableDeclaration) runtime.Value {
+	var result runtime.Value
 
-	variableName := vd.VariableNode.GetToken().Value
+	var value runtime.Value
 
-	varType := vd.TypeNode.GetToken().Value
+	if vd.Initializer != nil {
+		value = i.Visit(vd.Initializer)
+	} else if vd.TypeNode != nil && IsOptionalType(vd.TypeNode.GetToken().Value) {
+		value = runtime.None
+	}
 
-	// helpers.ColorPrint(constants.Blue, 1, 1, varType, " ", constants.SpewPrinter.Sdump(vd))
+	// let x: float = 1;
+	if value.Kind == runtime.INT && vd.TypeNode != nil && NonOptionalType(vd.TypeNode.GetToken().Value) == constants.FLOAT_TYPE {
+		value = runtime.FloatValue(float32(value.Int))
+	}
 
-	activationRecord, _ := i.CallStack.Peek()
+	// helpers.ColorPrint(constants.Blue, 1, 1, constants.SpewPrinter.Sdump(vd))
 
-	arValue := map[string]interface{}{
-		constants.AR_KEY_TYPE:  varType,
-		constants.AR_KEY_VALUE: nil,
-	}
+	activationRecord, _ := i.CallStack.Peek()
 
-	activationRecord.SetItem(variableName, arValue, true)
+	activationRecord.SetItem(0, vd.VariableNode.(Variable).Binding.Slot, value)
 
 	// helpers.ColorPrint(constants.Blue, 1, 1, varType, " ", constants.SpewPrinter.Sdump(activationRecord))
 
 	return result
 }
 
-func (i *Interpreter) EvaluateCompoundStatement(cs CompoundStatement) interface{} {
-	var result interface{}
+func (i *Interpreter) EvaluateCompoundStatement(cs CompoundStatement) runtime.Value {
+	var result runtime.Value
 
 	// fmt.Println("found CompoundStatement")
 	// i.spewPrinter.Dump(node)
@@ -197,10 +240,10 @@ func (i *Interpreter) EvaluateCompoundStatement(cs CompoundStatement) interface{
 	return result
 }
 
-func (i *Interpreter) EvaluateAssignmentStatement(as AssignmentStatement) interface{} {
-	var result interface{}
+func (i *Interpreter) EvaluateAssignmentStatement(as AssignmentStatement) runtime.Value {
+	var result runtime.Value
 
-	variableName := as.Left.GetToken().Value
+	binding := as.Left.(Variable).Binding
 
 	variableValue := i.Visit(as.Right)
 
@@ -208,28 +251,30 @@ func (i *Interpreter) EvaluateAssignmentStatement(as AssignmentStatement) interf
 
 	// helpers.ColorPrint(constants.Blue, 1, 1, constants.SpewPrinter.Sdump(as))
 
-	arValue := map[string]interface{}{
-		constants.AR_KEY_TYPE:  "varType",
-		constants.AR_KEY_VALUE: variableValue,
-	}
-
-	activationRecord.SetItem(variableName, arValue, false)
+	activationRecord.SetItem(binding.Depth, binding.Slot, variableValue)
 
 	return result
 }
 
-func (i *Interpreter) EvaluateVariable(v Variable) interface{} {
-	var result interface{}
+func (i *Interpreter) EvaluateVariable(v Variable) runtime.Value {
+	var result runtime.Value
 
 	// if we encounter a variable, look for it in the GlobalScope and respond accordingly
 	variableName := v.Token.Value
 
 	activationRecord, _ := i.CallStack.Peek()
 
-	varValue, exists := activationRecord.GetItem(variableName)
+	varValue, exists := activationRecord.GetItem(v.Binding.Depth, v.Binding.Slot)
 
 	if exists {
-		result = varValue[constants.AR_KEY_VALUE]
+		result = varValue
+
+		// the declared but never assigned variables which the scoping pass couldn't catch
+		if result.IsNil() {
+			usedBeforeAssignment(v)
+		}
+	} else if value, isConstant := NativeConstants[variableName]; isConstant {
+		result = value
 	} else {
 		errors.ShowError(
 			constants.SEMANTIC_ERROR,
@@ -242,12 +287,41 @@ func (i *Interpreter) EvaluateVariable(v Variable) interface{} {
 	return result
 }
 
-func (i *Interpreter) EvaluateLogicalStatement(l LogicalNode) interface{} {
+func usedBeforeAssignment(v Variable) {
+	errors.ShowError(
+		constants.RUNTIME_ERROR,
+		constants.ERROR_USED_BEFORE_ASSIGNMENT,
+		fmt.Sprintf("variable '%s' used before assignment", v.Value),
+		v.Token,
+	)
+}
+
+func (i *Interpreter) EvaluateLogicalStatement(l LogicalNode) runtime.Value {
+	left := i.Visit(l.Left)
+
+	if decidesLogical(l, left) {
+		return left
+	}
+
+	right := i.Visit(l.Right)
+
+	return logicalOperation(l, left, right)
+}
+
+/*
+	Whether the left operand alone is the result, false for an and and true for an or. The right operand isn't
+	evaluated then, so it can rely on the left one, ex - x != none and x > 1
+*/
+func decidesLogical(l LogicalNode, left runtime.Value) bool {
+	return left.Kind == runtime.BOOL && left.Bool == (l.LogicalOperator.Type == constants.OR)
+}
 
-	var result interface{}
+// applies the logical operator of l to the evaluated operands
+func logicalOperation(l LogicalNode, left, right runtime.Value) runtime.Value {
+	var result runtime.Value
 
-	leftResult, lok := i.Visit(l.Left).(bool)
-	rightResult, rok := i.Visit(l.Right).(bool)
+	leftResult, lok := left.Bool, left.Kind == runtime.BOOL
+	rightResult, rok := right.Bool, right.Kind == runtime.BOOL
 
 	// helpers.ColorPrint(constants.Green, 2, "leftResult ", leftResult, " right result ", rightResult)
 
@@ -255,10 +329,10 @@ func (i *Interpreter) EvaluateLogicalStatement(l LogicalNode) interface{} {
 
 		switch l.LogicalOperator.Type {
 		case constants.AND:
-			result = leftResult && rightResult
+			result = runtime.BoolValue(leftResult && rightResult)
 
 		case constants.OR:
-			result = leftResult || rightResult
+			result = runtime.BoolValue(leftResult || rightResult)
 
 		}
 
@@ -267,14 +341,14 @@ func (i *Interpreter) EvaluateLogicalStatement(l LogicalNode) interface{} {
 	return result
 }
 
-func (i *Interpreter) EvaluateConditionalStatement(c ConditionalStatement) interface{} {
-	var result interface{}
+func (i *Interpreter) EvaluateConditionalStatement(c ConditionalStatement) runtime.Value {
+	var result runtime.Value
 	var (
 		elseBlock ConditionalStatement
 		visitElse bool = false
 	)
 
-	enterBlock, _ := i.Visit(c.Conditionals).(bool)
+	enterBlock := i.Visit(c.Conditionals).Bool
 
 	if enterBlock {
 		result = i.Visit(c.ConditionalBlock)
@@ -290,7 +364,7 @@ func (i *Interpreter) EvaluateConditionalStatement(c ConditionalStatement) inter
 				visitElse = true
 			}
 
-			enterInnerBlock, _ := i.Visit(statement.Conditionals).(bool)
+			enterInnerBlock := i.Visit(statement.Conditionals).Bool
 
 			if enterInnerBlock {
 				result = i.Visit(statement.ConditionalBlock)
@@ -308,7 +382,7 @@ func (i *Interpreter) EvaluateConditionalStatement(c ConditionalStatement) inter
 	return result
 }
 
-func (i *Interpreter) EvaluateRangeLoop(l RangeLoop) interface{} {
+func (i *Interpreter) EvaluateRangeLoop(l RangeLoop) runtime.Value {
 	// helpers.ColorPrint(constants.LightYellow, 1, 1, "loop = ", constants.SpewPrinter.Sdump(l))
 
 	var (
@@ -316,72 +390,60 @@ func (i *Interpreter) EvaluateRangeLoop(l RangeLoop) interface{} {
 		high int
 	)
 
-	visitLow := i.Visit(l.Low)
-	visitHigh := i.Visit(l.High)
-
-	if val, ok := visitLow.(float32); ok {
-		low = int(val)
-	} else if val, ok := visitLow.(float64); ok {
-		low = int(val)
-	} else {
-		low = visitLow.(int)
-	}
-
-	if val, ok := visitHigh.(float32); ok {
-		high = int(val)
-	} else if val, ok := visitHigh.(float64); ok {
-		high = int(val)
-	} else {
-		high = visitHigh.(int)
-	}
-
-	iteratorName := l.IdentifierToken.Value
+	low = loopBound(i.Visit(l.Low))
+	high = loopBound(i.Visit(l.High))
 
 	topAr, _ := i.CallStack.Peek()
 
-	ar := callstack.ActivationRecord{
-		Name:         constants.AR_LOOP,
-		Type:         constants.AR_LOOP,
-		NestingLevel: topAr.NestingLevel + 1,
-		AboveNode:    &topAr,
-	}
-	ar.Init()
-
-	i.CallStack.Push(ar)
-
-	arValue := map[string]interface{}{
-		constants.AR_KEY_TYPE:  constants.INTEGER,
-		constants.AR_KEY_VALUE: low,
-	}
-	ar.SetItem(iteratorName, arValue, true)
-
 	// helpers.ColorPrint(constants.LightGreen, 1, 1, constants.SpewPrinter.Sdump(i.CallStack))
 
-	var result interface{}
+	var result runtime.Value
 
 	for counter := int(low); counter <= int(high); counter++ {
-		arValue := map[string]interface{}{
-			constants.AR_KEY_TYPE:  constants.INTEGER,
-			constants.AR_KEY_VALUE: counter,
+		// every iteration gets its own record, so functions created in the body keep that iteration's variables
+		ar := &callstack.ActivationRecord{
+			Name:         constants.AR_LOOP,
+			Type:         constants.AR_LOOP,
+			NestingLevel: topAr.NestingLevel + 1,
+			AboveNode:    topAr,
 		}
-		ar.SetItem(iteratorName, arValue, true)
+		ar.Init()
+
+		i.CallStack.Push(ar)
+
+		// the iterator is always in slot 0 of the loop's record
+		ar.SetItem(0, 0, runtime.IntValue(counter))
 
 		i.Visit(l.Block)
-	}
 
-	i.CallStack.Pop()
+		i.CallStack.Pop()
+	}
 
 	return result
 }
 
-func (i *Interpreter) EvaluateBinaryOperationNode(b BinaryOperationNode) interface{} {
-	i.TypeCheckBinaryOperationNode(b)
+// the bounds of range loops are truncated to integers
+func loopBound(value runtime.Value) int {
+	if value.Kind == runtime.FLOAT {
+		return int(value.Float)
+	}
 
-	var result interface{}
+	return value.Int
+}
 
+func (i *Interpreter) EvaluateBinaryOperationNode(b BinaryOperationNode) runtime.Value {
 	leftVisit := i.Visit(b.Left)
 	rightVisit := i.Visit(b.Right)
 
+	return i.binaryOperation(b, leftVisit, rightVisit)
+}
+
+// applies the operator of b to the evaluated operands
+func (i *Interpreter) binaryOperation(b BinaryOperationNode, leftVisit, rightVisit runtime.Value) runtime.Value {
+	var result runtime.Value
+
+	i.TypeCheckBinaryOperationNode(b, leftVisit, rightVisit)
+
 	var (
 		leftResult     float32
 		leftIntResult  int
@@ -394,23 +456,13 @@ func (i *Interpreter) EvaluateBinaryOperationNode(b BinaryOperationNode) interfa
 
 	// 6 ^ 2 - 16
 
-	leftResult, isLeftFloat = leftVisit.(float32)
-	rightResult, _ = rightVisit.(float32)
+	leftResult, isLeftFloat = leftVisit.Number(), leftVisit.Kind == runtime.FLOAT
+	rightResult = rightVisit.Number()
 
-	leftIntResult, isLeftInt = leftVisit.(int)
-	rightIntResult, isRightInt = rightVisit.(int)
-
-	if isLeftInt {
-		leftResult = float32(leftIntResult)
-	}
-
-	if isRightInt {
-		rightResult = float32(rightIntResult)
-	}
+	leftIntResult, isLeftInt = leftVisit.Int, leftVisit.Kind == runtime.INT
+	rightIntResult, isRightInt = rightVisit.Int, rightVisit.Kind == runtime.INT
 
 	divideByZero := func() {
-
-		fmt.Print(b.Right.GetToken())
 		errors.ShowError(
 			constants.RUNTIME_ERROR,
 			constants.LOGICAL_ERROR,
@@ -422,40 +474,55 @@ func (i *Interpreter) EvaluateBinaryOperationNode(b BinaryOperationNode) interfa
 	switch b.Operation.Type {
 	case constants.PLUS:
 		{
-			if isLeftFloat || isLeftInt {
-				result = leftResult + rightResult
+			if isLeftInt && isRightInt {
+				result = runtime.IntValue(leftIntResult + rightIntResult)
+			} else if isLeftFloat || isLeftInt {
+				result = runtime.FloatValue(leftResult + rightResult)
+			} else if leftVisit.Kind == runtime.LIST {
+				// a new list, the operands are left as they are
+				elements := append([]runtime.Value{}, leftVisit.List...)
+				result = runtime.ListValue(append(elements, rightVisit.List...))
 			} else {
 				// TODO: left and right are string
 				s := ""
 
-				if ls, ok := leftVisit.(string); ok {
+				if leftVisit.Kind == runtime.STRING {
+					ls := leftVisit.Str
 					for i := 0; i < len(ls); i++ {
 						s += string(ls[i])
 					}
 				}
 
-				if rs, ok := rightVisit.(string); ok {
+				if rightVisit.Kind == runtime.STRING {
+					rs := rightVisit.Str
 					for i := 0; i < len(rs); i++ {
 						s += string(rs[i])
 					}
 				}
 
-				result = s
+				result = runtime.StringValue(s)
 			}
 		}
 
 	case constants.MINUS:
-		result = leftResult - rightResult
+		if isLeftInt && isRightInt {
+			result = runtime.IntValue(leftIntResult - rightIntResult)
+		} else {
+			result = runtime.FloatValue(leftResult - rightResult)
+		}
 
 	case constants.MUL:
 		{
-			if isLeftFloat || isLeftInt {
-				result = leftResult * rightResult
+			if isLeftInt && isRightInt {
+				result = runtime.IntValue(leftIntResult * rightIntResult)
+			} else if isLeftFloat || isLeftInt {
+				result = runtime.FloatValue(leftResult * rightResult)
 			} else {
 				// TODO: left and right are string
 				temp := ""
 
-				if ls, ok := leftVisit.(string); ok {
+				if leftVisit.Kind == runtime.STRING {
+					ls := leftVisit.Str
 					for i := 0; i < len(ls); i++ {
 						temp += string(ls[i])
 					}
@@ -463,95 +530,62 @@ func (i *Interpreter) EvaluateBinaryOperationNode(b BinaryOperationNode) interfa
 
 				s := ""
 
-				if rightInt, ok := rightVisit.(int); ok {
-					for i := 0; i < rightInt; i++ {
+				if isRightInt {
+					for i := 0; i < rightIntResult; i++ {
 						s += temp
 					}
 				}
 
-				result = s
+				result = runtime.StringValue(s)
 			}
 		}
 
 	case constants.EXPONENT:
-		result = float32(math.Pow(float64(leftResult), float64(rightResult)))
+		result = runtime.FloatValue(float32(math.Pow(float64(leftResult), float64(rightResult))))
 
 	case constants.FLOAT_DIV:
 		if rightResult == 0.0 {
 			divideByZero()
 		}
-		result = leftResult / rightResult
+		result = runtime.FloatValue(leftResult / rightResult)
 
 	case constants.INTEGER_DIV:
 		if rightResult == 0.0 {
 			divideByZero()
 		}
-		result = int(leftResult / rightResult)
+		result = runtime.IntValue(int(leftResult / rightResult))
 
 	case constants.MODULO:
-		result = int(leftResult) % int(rightResult)
+		if int(rightResult) == 0 {
+			divideByZero()
+		}
+		result = runtime.IntValue(int(leftResult) % int(rightResult))
 
 	}
 
 	return result
 }
 
-func (i *Interpreter) EvaluateComparisonNode(c ComparisonNode) interface{} {
-	i.TypeCheckComparisonOperationNode(c)
-
-	var result interface{}
-
+func (i *Interpreter) EvaluateComparisonNode(c ComparisonNode) runtime.Value {
 	leftVisit := i.Visit(c.Left)
 	rightVisit := i.Visit(c.Right)
 
-	var (
-		leftResult      float32
-		leftIntResult   int
-		rightResult     float32
-		rightIntResult  int
-		isLeftInt       bool
-		isRightInt      bool
-		isLeftStr       bool
-		leftStrResult   string
-		rightStrResult  string
-		areStringsEqual bool = true
-	)
-
-	leftResult, _ = leftVisit.(float32)
-	rightResult, _ = rightVisit.(float32)
-
-	leftIntResult, isLeftInt = leftVisit.(int)
-	rightIntResult, isRightInt = rightVisit.(int)
-
-	leftStrResult, isLeftStr = leftVisit.(string)
-	rightStrResult, _ = rightVisit.(string)
-
-	if isLeftInt {
-		leftResult = float32(leftIntResult)
-	}
-
-	if isRightInt {
-		rightResult = float32(rightIntResult)
-	}
-
-	if isLeftStr {
-		leftResult = float32(len(leftStrResult))
-		rightResult = float32(len(rightStrResult))
+	return i.comparison(c, leftVisit, rightVisit)
+}
 
-		// check for string equality right here
+// applies the comparator of c to the evaluated operands
+func (i *Interpreter) comparison(c ComparisonNode, leftVisit, rightVisit runtime.Value) runtime.Value {
+	var result bool
 
-		if leftResult != rightResult {
-			areStringsEqual = false
-		} else {
-			for i := 0; i < len(leftStrResult); i++ {
-				if leftStrResult[i] != rightStrResult[i] {
-					areStringsEqual = false
-					break
-				}
-			}
+	i.TypeCheckComparisonOperationNode(c, leftVisit, rightVisit)
 
-		}
+	leftResult := leftVisit.Number()
+	rightResult := rightVisit.Number()
 
+	// strings are ordered by their length
+	if leftVisit.Kind == runtime.STRING {
+		leftResult = float32(len(leftVisit.Str))
+		rightResult = float32(len(rightVisit.Str))
 	}
 
 	switch c.Comparator.Type {
@@ -568,19 +602,73 @@ func (i *Interpreter) EvaluateComparisonNode(c ComparisonNode) interface{} {
 		result = leftResult <= rightResult
 
 	case constants.EQUALITY:
-		if isLeftStr {
-			result = areStringsEqual
-		} else {
-			result = leftResult == rightResult
-		}
+		result = leftVisit.Equal(rightVisit)
 
 	case constants.NOT_EQUAL_TO:
-		if isLeftStr {
-			result = !areStringsEqual
-		} else {
-			result = leftResult != rightResult
+		result = !leftVisit.Equal(rightVisit)
+
+	}
+
+	return runtime.BoolValue(result)
+}
+
+func (i *Interpreter) EvaluateIndexNode(in IndexNode) runtime.Value {
+	left := i.Visit(in.Left)
+	indexValue := i.Visit(in.Index)
+
+	return indexInto(in, left, indexValue)
+}
+
+// indexes the evaluated left hand side of in
+func indexInto(in IndexNode, left, indexValue runtime.Value) runtime.Value {
+	var result runtime.Value
+
+	index, ok := indexValue.Int, indexValue.Kind == runtime.INT
+
+	if !ok {
+		if f := indexValue.Float; indexValue.Kind == runtime.FLOAT && f == float32(int(f)) {
+			index, ok = int(f), true
 		}
+	}
 
+	if !ok {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.TYPE_ERROR,
+			fmt.Sprintf("Index must be an integer, got '%v'", indexValue),
+			in.Token,
+		)
+	}
+
+	outOfRange := func(length int) {
+		errors.ShowError(
+			constants.RUNTIME_ERROR,
+			constants.ERROR_INDEX_OUT_OF_RANGE,
+			fmt.Sprintf("Index %d out of range for length %d", index, length),
+			in.Token,
+		)
+	}
+
+	switch left.Kind {
+	case runtime.LIST:
+		if index < 0 || index >= len(left.List) {
+			outOfRange(len(left.List))
+		}
+		result = left.List[index]
+
+	case runtime.STRING:
+		if index < 0 || index >= len(left.Str) {
+			outOfRange(len(left.Str))
+		}
+		result = runtime.StringValue(string(left.Str[index]))
+
+	default:
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.TYPE_ERROR,
+			fmt.Sprintf("Value '%v' cannot be indexed", left),
+			in.Token,
+		)
 	}
 
 	return result
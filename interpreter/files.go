@@ -0,0 +1,200 @@
+package interpreter
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"programminglang/constants"
+	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
+)
+
+// the same limit as filepath.EvalSymlinks
+const maxSymlinks = 255
+
+func fileError(f FunctionCall, message string) {
+	errors.ShowError(
+		constants.RUNTIME_ERROR,
+		constants.ERROR_FILE_ACCESS,
+		fmt.Sprintf("%s: %s", f.FunctionName, message),
+		f.Token,
+	)
+}
+
+/*
+	Resolves symlinks in the longest part of the path that exists, the rest is appended as is.
+
+	A dangling symlink is resolved to the path it points to, as creating the file would create its target
+*/
+func resolveExisting(path string) (string, error) {
+	return resolveLinks(path, 0)
+}
+
+// links is the number of dangling symlinks followed so far
+func resolveLinks(path string, links int) (string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+
+	if err == nil {
+		return resolved, nil
+	}
+
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		if links == maxSymlinks {
+			return "", fmt.Errorf("too many links in '%s'", path)
+		}
+
+		target, err := os.Readlink(path)
+
+		if err != nil {
+			return "", err
+		}
+
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(path), target)
+		}
+
+		return resolveLinks(target, links+1)
+	}
+
+	parent := filepath.Dir(path)
+
+	if parent == path {
+		return path, nil
+	}
+
+	resolvedParent, err := resolveLinks(parent, links)
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(resolvedParent, filepath.Base(path)), nil
+}
+
+/*
+	Resolves a path used by a script against Interpreter.FileRoot.
+
+	File access is disabled if FileRoot is empty, and paths that end up outside of it,
+	ex - "../secret" or a symlink pointing elsewhere, are rejected
+*/
+func (i *Interpreter) resolvePath(f FunctionCall, path string) string {
+	if i.FileRoot == "" {
+		fileError(f, "file access is disabled")
+	}
+
+	root, err := filepath.Abs(i.FileRoot)
+
+	if err == nil {
+		root, err = resolveExisting(root)
+	}
+
+	if err != nil {
+		fileError(f, fmt.Sprintf("invalid root directory '%s'", i.FileRoot))
+	}
+
+	resolved, err := resolveExisting(filepath.Join(root, path))
+
+	if err != nil {
+		fileError(f, err.Error())
+	}
+
+	relative, err := filepath.Rel(root, resolved)
+
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		fileError(f, fmt.Sprintf("'%s' is outside of the root directory", path))
+	}
+
+	return resolved
+}
+
+// all the file functions take the path as their first argument, followed by strings
+func fileArguments(f FunctionCall, args []runtime.Value, count int) []string {
+	var strArgs []string
+
+	for _, arg := range args {
+		if arg.Kind == runtime.STRING {
+			strArgs = append(strArgs, arg.Str)
+		}
+	}
+
+	if len(args) != count || len(strArgs) != count {
+		fileError(f, fmt.Sprintf("expects %d string arguments", count))
+	}
+
+	return strArgs
+}
+
+func nativeReadFile(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	path := i.resolvePath(f, fileArguments(f, args, 1)[0])
+
+	data, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		fileError(f, err.Error())
+	}
+
+	return runtime.StringValue(string(data))
+}
+
+func writeFile(i *Interpreter, f FunctionCall, args []runtime.Value, flag int) {
+	strArgs := fileArguments(f, args, 2)
+	path := i.resolvePath(f, strArgs[0])
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|flag, 0644)
+
+	if err != nil {
+		fileError(f, err.Error())
+	}
+
+	defer file.Close()
+
+	if _, err := file.WriteString(strArgs[1]); err != nil {
+		fileError(f, err.Error())
+	}
+}
+
+// write_file(path, content) replaces the contents of the file
+func nativeWriteFile(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	writeFile(i, f, args, os.O_TRUNC)
+	return runtime.Nil
+}
+
+// append_file(path, content) adds content to the end of the file
+func nativeAppendFile(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	writeFile(i, f, args, os.O_APPEND)
+	return runtime.Nil
+}
+
+// list_dir(path) returns the sorted names of the entries in the directory
+func nativeListDir(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	path := i.resolvePath(f, fileArguments(f, args, 1)[0])
+
+	entries, err := ioutil.ReadDir(path)
+
+	if err != nil {
+		fileError(f, err.Error())
+	}
+
+	names := []runtime.Value{}
+
+	for _, entry := range entries {
+		names = append(names, runtime.StringValue(entry.Name()))
+	}
+
+	return runtime.ListValue(names)
+}
+
+func nativeFileExists(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	path := i.resolvePath(f, fileArguments(f, args, 1)[0])
+
+	_, err := os.Stat(path)
+
+	return runtime.BoolValue(err == nil)
+}
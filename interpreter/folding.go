@@ -0,0 +1,196 @@
+package interpreter
+
+import (
+	"strconv"
+
+	"programminglang/constants"
+	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
+	"programminglang/types"
+)
+
+// what the scoping pass found out about a variable where it's used
+type Binding struct {
+	Type     string        // the variable's type where it's used
+	Constant bool          // the variable is a constant, its uses are replaced with Value
+	Value    runtime.Value // value of the constant
+
+	// the number of activation records between the use and the declaration, and the slot in that record.
+	// For function calls, where the function value is stored
+	Depth int
+	Slot  int
+
+	Native *NativeFunction // set for calls of built in functions
+
+	// for calls whose arguments aren't in the order of the parameters, the index in ActualParameters of every
+	// parameter's argument, -1 where the parameter's value in Defaults is used
+	Arguments []int
+	Defaults  []runtime.Value
+
+	// for calls of variadic functions, the indexes of the arguments collected into the list of the variadic parameter
+	Rest []int
+
+	Enum *runtime.Enum // for Color.from_ordinal(n), the enum of the member
+}
+
+/*
+	Replaces the uses of constants with their values, and evaluates the operations whose operands are all
+	literals. Ex - with const LIMIT: int = 100; LIMIT * 2 becomes 200.
+
+	Runs after scoping, the tree has to be scoped for the constants to be known
+*/
+func (i *Interpreter) FoldConstants(node AbstractSyntaxTree) AbstractSyntaxTree {
+	return transformTree(node, func(n AbstractSyntaxTree) AbstractSyntaxTree {
+		return i.foldLiterals(substituteConstant(n))
+	})
+}
+
+// only replaces the uses of constants with their values
+func (i *Interpreter) SubstituteConstants(node AbstractSyntaxTree) AbstractSyntaxTree {
+	return transformTree(node, substituteConstant)
+}
+
+/*
+	Rewrites the tree bottom up, every node is passed to rewrite after its children have been rewritten.
+
+	Function declarations and literals are skipped, their bodies are rewritten while scoping since that's
+	where the function symbol gets them from
+*/
+func transformTree(node AbstractSyntaxTree, rewrite func(AbstractSyntaxTree) AbstractSyntaxTree) AbstractSyntaxTree {
+	switch node.(type) {
+	case FunctionDeclaration, FunctionLiteral:
+		return node
+	}
+
+	return rewrite(MapChildren(node, func(child AbstractSyntaxTree) AbstractSyntaxTree {
+		return transformTree(child, rewrite)
+	}))
+}
+
+func substituteConstant(node AbstractSyntaxTree) AbstractSyntaxTree {
+	if v, ok := node.(Variable); ok && v.Binding != nil && v.Binding.Constant {
+		if literal, ok := literalNode(v.Binding.Value, v.Token); ok {
+			return literal
+		}
+	}
+
+	return node
+}
+
+// evaluates an operation if all of its operands are literals
+func (i *Interpreter) foldLiterals(node AbstractSyntaxTree) AbstractSyntaxTree {
+	switch node.(type) {
+	case BinaryOperationNode, UnaryOperationNode, ComparisonNode, LogicalNode, CoalesceNode, ConditionalExpression, TypeConversion:
+		for _, child := range Children(node) {
+			if _, ok := literalValue(child); !ok {
+				return node
+			}
+		}
+
+		if value, ok := i.tryEvaluate(node); ok {
+			if literal, ok := literalNode(value, node.GetToken()); ok {
+				return literal
+			}
+		}
+	}
+
+	return node
+}
+
+/*
+	Evaluates a node made up of only literals. If that errors, ex - 1 / 0, the node is left as it is
+	so the error is reported when the program gets there
+*/
+func (i *Interpreter) tryEvaluate(node AbstractSyntaxTree) (value runtime.Value, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, isError := r.(errors.ErrorInterface); !isError {
+				panic(r)
+			}
+
+			value, ok = runtime.Nil, false
+		}
+	}()
+
+	return i.Visit(node), true
+}
+
+// the value of a literal node
+func literalValue(node AbstractSyntaxTree) (runtime.Value, bool) {
+	switch n := node.(type) {
+	case IntegerNumber:
+		return runtime.IntValue(n.Token.IntegerValue), true
+
+	case FloatNumber:
+		return runtime.FloatValue(n.Token.FloatValue), true
+
+	case String:
+		return runtime.StringValue(n.Token.Value), true
+
+	case Boolean:
+		return runtime.BoolValue(n.Value), true
+
+	case NoneLiteral:
+		return runtime.None, true
+
+	case EnumMember:
+		if n.Binding != nil && n.Binding.Constant {
+			return n.Binding.Value, true
+		}
+	}
+
+	return runtime.Nil, false
+}
+
+// a literal node for the value, placed at the position of token
+func literalNode(value runtime.Value, token types.Token) (AbstractSyntaxTree, bool) {
+	literalToken := types.Token{
+		LineNumber: token.LineNumber,
+		Column:     token.Column,
+	}
+
+	switch value.Kind {
+	case runtime.INT:
+		v := value.Int
+		literalToken.Type = constants.INTEGER
+		literalToken.Value = strconv.Itoa(v)
+		literalToken.IntegerValue = v
+
+		return IntegerNumber{Token: literalToken, Value: v}, true
+
+	case runtime.FLOAT:
+		v := value.Float
+		literalToken.Type = constants.FLOAT
+		literalToken.Value = value.String()
+		literalToken.FloatValue = v
+
+		return FloatNumber{Token: literalToken, Value: v}, true
+
+	case runtime.STRING:
+		v := value.Str
+		literalToken.Type = constants.STRING
+		literalToken.Value = v
+
+		return String{Token: literalToken, Value: v}, true
+
+	case runtime.BOOL:
+		v := value.Bool
+		literalToken.Type = constants.FALSE
+		literalToken.Value = constants.FALSE
+
+		if v {
+			literalToken.Type = constants.TRUE
+			literalToken.Value = constants.TRUE
+		}
+
+		return Boolean{Token: literalToken, Value: v}, true
+
+	case runtime.NONE:
+		literalToken.Type = constants.NONE
+		literalToken.Value = constants.NONE
+
+		return NoneLiteral{Token: literalToken}, true
+	}
+
+	return nil, false
+}
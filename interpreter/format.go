@@ -0,0 +1,235 @@
+package interpreter
+
+import (
+	"fmt"
+	"strings"
+
+	"programminglang/constants"
+	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
+	"programminglang/types"
+)
+
+// a single directive in a printf format string. Ex - %-10s
+type formatDirective struct {
+	Spec string // the whole directive including flags, width and precision
+	Verb string
+}
+
+/*
+	Splits a printf format string into its directives. "%%" prints a percent sign and isn't a directive.
+
+	Returns an error message if the format string is malformed
+*/
+func parseFormat(format string) ([]formatDirective, string) {
+	var directives []formatDirective
+
+	for index := 0; index < len(format); index++ {
+		if format[index] != '%' {
+			continue
+		}
+
+		start := index
+		index++
+
+		if index < len(format) && format[index] == '%' {
+			continue
+		}
+
+		for index < len(format) && strings.IndexByte(constants.FORMAT_FLAGS, format[index]) != -1 {
+			index++
+		}
+
+		for index < len(format) && format[index] >= '0' && format[index] <= '9' {
+			index++
+		}
+
+		if index < len(format) && format[index] == '.' {
+			index++
+
+			for index < len(format) && format[index] >= '0' && format[index] <= '9' {
+				index++
+			}
+		}
+
+		if index >= len(format) {
+			return nil, fmt.Sprintf("missing verb at the end of '%s'", format[start:])
+		}
+
+		verb := string(format[index])
+
+		if _, ok := constants.FORMAT_VERB_TYPES[verb]; !ok && verb != constants.FORMAT_ANY_VERB {
+			return nil, fmt.Sprintf("unknown verb '%s' in '%s'", verb, format[start:index+1])
+		}
+
+		directives = append(directives, formatDirective{
+			Spec: format[start : index+1],
+			Verb: verb,
+		})
+	}
+
+	return directives, ""
+}
+
+/*
+	Validates the format string against the types of the arguments to be formatted.
+
+	An empty argument type means the type isn't known yet and is not checked
+*/
+func checkFormat(format string, argTypes []string) string {
+	directives, message := parseFormat(format)
+
+	if message != "" {
+		return message
+	}
+
+	if len(directives) != len(argTypes) {
+		return fmt.Sprintf(
+			"format '%s' has %d verbs but %d arguments were given",
+			format, len(directives), len(argTypes),
+		)
+	}
+
+	for index, directive := range directives {
+		argType := argTypes[index]
+
+		if directive.Verb == constants.FORMAT_ANY_VERB || argType == "" {
+			continue
+		}
+
+		if !constants.FORMAT_VERB_TYPES[directive.Verb][argType] {
+			return fmt.Sprintf(
+				"verb '%s' cannot format argument %d of type %s",
+				directive.Spec, index+1, argType,
+			)
+		}
+	}
+
+	return ""
+}
+
+// static check for printf and sprintf, the format can only be checked if it's a string literal
+func checkFormatCall(i *Interpreter, f FunctionCall, argTypes []string) {
+	if len(f.ActualParameters) == 0 {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_INVALID_FORMAT,
+			fmt.Sprintf("%s expects a format string", f.FunctionName),
+			f.Token,
+		)
+	}
+
+	if argTypes[0] != "" && argTypes[0] != constants.STRING_TYPE {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_INVALID_FORMAT,
+			fmt.Sprintf("%s expects a format string, got %s", f.FunctionName, argTypes[0]),
+			f.Token,
+		)
+	}
+
+	format, ok := f.ActualParameters[0].(String)
+
+	if !ok {
+		return
+	}
+
+	if message := checkFormat(format.Value, argTypes[1:]); message != "" {
+		formatError(f.FunctionName, message, constants.TYPE_ERROR, f.Token)
+	}
+}
+
+func formatError(functionName, message, errorType string, token types.Token) {
+	errors.ShowError(
+		errorType,
+		constants.ERROR_INVALID_FORMAT,
+		fmt.Sprintf("%s: %s", functionName, message),
+		token,
+	)
+}
+
+// formats the arguments at runtime, the first argument is the format string
+func sprintf(f FunctionCall, args []runtime.Value) string {
+	format, ok := args[0].Str, args[0].Kind == runtime.STRING
+
+	if !ok {
+		formatError(f.FunctionName, fmt.Sprintf("expected a format string, got '%v'", args[0]), constants.RUNTIME_ERROR, f.Token)
+	}
+
+	values := args[1:]
+
+	var argTypes []string
+
+	for _, value := range values {
+		argTypes = append(argTypes, value.TypeName())
+	}
+
+	// the static check is skipped when the format isn't a literal, so check again
+	if message := checkFormat(format, argTypes); message != "" {
+		formatError(f.FunctionName, message, constants.RUNTIME_ERROR, f.Token)
+	}
+
+	directives, _ := parseFormat(format)
+
+	var converted []interface{}
+
+	for index, value := range values {
+		// integers are allowed for the floating point verbs
+		if value.Kind == runtime.INT && constants.FORMAT_VERB_TYPES[directives[index].Verb][constants.FLOAT_TYPE] {
+			converted = append(converted, float64(value.Int))
+			continue
+		}
+
+		converted = append(converted, value.Interface())
+	}
+
+	return fmt.Sprintf(format, converted...)
+}
+
+// both can be given the string between the values and the one after them, ex - print("a", 1, sep = ",", end = "\n")
+var printParams = map[string]string{
+	constants.SEPARATOR: constants.STRING_TYPE,
+	constants.LINE_END:  constants.STRING_TYPE,
+}
+
+func nativePrint(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	values, named := nativeArguments(f, args)
+
+	printValues(i, values, named, fmt.Sprint(runtime.Interfaces(values)...), "")
+	return runtime.Nil
+}
+
+func nativePrintLine(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	values, named := nativeArguments(f, args)
+
+	printValues(i, values, named, strings.TrimSuffix(fmt.Sprintln(runtime.Interfaces(values)...), "\n"), "\n")
+	return runtime.Nil
+}
+
+// writes text, or the values joined with sep if it's given, followed by end or lineEnding
+func printValues(i *Interpreter, values []runtime.Value, named map[string]runtime.Value, text, lineEnding string) {
+	if sep, ok := named[constants.SEPARATOR]; ok {
+		var parts []string
+
+		for _, value := range values {
+			parts = append(parts, fmt.Sprint(value.Interface()))
+		}
+
+		text = strings.Join(parts, sep.Str)
+	}
+
+	if end, ok := named[constants.LINE_END]; ok {
+		lineEnding = end.Str
+	}
+
+	fmt.Fprint(i.GetStdout(), text, lineEnding)
+}
+
+func nativePrintFormat(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	fmt.Fprint(i.GetStdout(), sprintf(f, args))
+	return runtime.Nil
+}
+
+func nativeSprintFormat(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	return runtime.StringValue(sprintf(f, args))
+}
@@ -0,0 +1,163 @@
+package interpreter
+
+import (
+	"strings"
+
+	"programminglang/constants"
+	"programminglang/interpreter/callstack"
+	"programminglang/interpreter/runtime"
+	"programminglang/types"
+)
+
+// an anonymous function, ex - fn(x: int) -> int { return x * 2; }
+type FunctionLiteral struct {
+	Token            types.Token // the FN token
+	FunctionBlock    AbstractSyntaxTree
+	FormalParameters []FunctionParameters
+	ReturningValue   AbstractSyntaxTree
+	ReturnTypeNode   AbstractSyntaxTree // a VariableType struct, nil if the return type is inferred
+	Symbol           *Symbol            // the function's symbol, filled in while scoping
+}
+
+// a function value of the tree walker, the function and the activation record it was created in
+type Closure struct {
+	Symbol *Symbol
+	Record *callstack.ActivationRecord
+}
+
+func (fl FunctionLiteral) GetToken() types.Token {
+	return fl.Token
+}
+
+func (fl FunctionLiteral) Scope(i *Interpreter) {
+	funcSymbol := Symbol{
+		FrameLevel: i.CurrentScope.Frame.Level,
+	}
+
+	// literals have no name to be defined with
+	*fl.Symbol = i.scopeFunction(funcSymbol, fl.FormalParameters, fl.FunctionBlock, fl.ReturningValue, fl.ReturnTypeNode, func(Symbol) {})
+}
+
+func (i *Interpreter) EvaluateFunctionLiteral(fl FunctionLiteral) runtime.Value {
+	activationRecord, _ := i.CallStack.Peek()
+
+	return functionValue(fl.Symbol, activationRecord)
+}
+
+// stores the function in its slot, so it can be called or used as a value
+func (i *Interpreter) EvaluateFunctionDeclaration(fn FunctionDeclaration) runtime.Value {
+	activationRecord, _ := i.CallStack.Peek()
+
+	activationRecord.SetItem(0, fn.Symbol.Slot, functionValue(fn.Symbol, activationRecord))
+
+	return runtime.Nil
+}
+
+func functionValue(symbol *Symbol, record *callstack.ActivationRecord) runtime.Value {
+	return runtime.FunctionValue(&runtime.Function{
+		Name:           symbol.Name,
+		Type:           symbol.Type,
+		Implementation: &Closure{Symbol: symbol, Record: record},
+	})
+}
+
+// the types of the function's parameters, in order
+func (s Symbol) ParamTypes() []string {
+	var paramTypes []string
+
+	for _, param := range s.ParamSymbols {
+		if param.Variadic {
+			// nums: ...int is an [int] in the function, fn(...int) in its type
+			paramTypes = append(paramTypes, constants.ELLIPSIS_SYMBOL+ListElementType(param.Type))
+			continue
+		}
+
+		paramTypes = append(paramTypes, param.Type)
+	}
+
+	return paramTypes
+}
+
+// the parameters of a function value, which only has their types. Ex - fn(str, ...int) -> [str, [int]]
+func paramSymbolsOf(paramTypes []string) []Symbol {
+	var params []Symbol
+
+	for _, paramType := range paramTypes {
+		param := Symbol{Type: paramType}
+
+		if IsVariadicType(paramType) {
+			param.Type = ListTypeOf(strings.TrimPrefix(paramType, constants.ELLIPSIS_SYMBOL))
+			param.Variadic = true
+			param.Value = runtime.ListValue([]runtime.Value{})
+		}
+
+		params = append(params, param)
+	}
+
+	return params
+}
+
+// whether a parameter type in a function type is variadic, ex - ...int
+func IsVariadicType(typeName string) bool {
+	return strings.HasPrefix(typeName, constants.ELLIPSIS_SYMBOL)
+}
+
+// returns the name of the type of a function. Ex - [int, int], int -> fn(int, int) -> int
+func FunctionTypeOf(paramTypes []string, returnType string) string {
+	typeName := constants.FN + constants.LPAREN_SYMBOL + strings.Join(paramTypes, constants.COMMA_SYMBOL+" ") + constants.RPAREN_SYMBOL
+
+	if returnType != "" {
+		typeName += " " + constants.ARROW_SYMBOL + " " + returnType
+	}
+
+	return typeName
+}
+
+func IsFunctionType(typeName string) bool {
+	return strings.HasPrefix(typeName, constants.FN+constants.LPAREN_SYMBOL)
+}
+
+// splits a function type into the types of its parameters and its return type. Ex - fn(int, [str]) -> int
+func FunctionTypeParts(typeName string) ([]string, string) {
+	var paramTypes []string
+
+	if !IsFunctionType(typeName) {
+		return paramTypes, ""
+	}
+
+	depth := 0
+	start := len(constants.FN + constants.LPAREN_SYMBOL)
+
+	// parameter types can be function or list types themselves, so only split at the outermost level
+	for index := start; index < len(typeName); index++ {
+		switch string(typeName[index]) {
+		case constants.LPAREN_SYMBOL, constants.LSQUARE_SYMBOL:
+			depth++
+
+		case constants.RSQUARE_SYMBOL:
+			depth--
+
+		case constants.COMMA_SYMBOL:
+			if depth == 0 {
+				paramTypes = append(paramTypes, strings.TrimSpace(typeName[start:index]))
+				start = index + 1
+			}
+
+		case constants.RPAREN_SYMBOL:
+			if depth > 0 {
+				depth--
+				continue
+			}
+
+			if param := strings.TrimSpace(typeName[start:index]); param != "" {
+				paramTypes = append(paramTypes, param)
+			}
+
+			returnType := strings.TrimPrefix(strings.TrimSpace(typeName[index+1:]), constants.ARROW_SYMBOL)
+
+			return paramTypes, strings.TrimSpace(returnType)
+		}
+	}
+
+	return paramTypes, ""
+}
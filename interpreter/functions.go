@@ -4,55 +4,109 @@ import (
 	"fmt"
 	"programminglang/constants"
 	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
 	"programminglang/types"
 )
 
 type FunctionParameters struct {
 	VariableNode AbstractSyntaxTree // a Variable struct
 	TypeNode     AbstractSyntaxTree // a VariableType struct
+	Default      AbstractSyntaxTree // optional, ex - port: int = 8080
+	Variadic     bool               // takes the rest of the arguments as a list, ex - nums: ...int
 }
 
 type FunctionDeclaration struct {
+	Token            types.Token // IDENTIFIER token for the function name
 	FunctionName     string
 	FunctionBlock    AbstractSyntaxTree // a Program struct
 	FormalParameters []FunctionParameters
 	ReturningValue   AbstractSyntaxTree
+	ReturnTypeNode   AbstractSyntaxTree // a VariableType struct, nil if the return type is inferred
+	Symbol           *Symbol            // the function's symbol, filled in while scoping
 }
 
 type FunctionCall struct {
 	FunctionName     string
 	ActualParameters []AbstractSyntaxTree
+	ArgumentNames    []string    // the name of each argument, "" if it's positional. nil if no argument is named
+	Spread           bool        // the last argument is a list passed as the variadic parameter, ex - sum(xs...)
 	Token            types.Token // IDENTIFIER token for the function name
 	FunctionSymbol   Symbol
+	Binding          *Binding // filled in while scoping
 }
 
 // function declaration
 
 func (fn FunctionDeclaration) GetToken() types.Token {
-	return types.Token{}
+	return fn.Token
 }
 
 func (fn FunctionDeclaration) Scope(i *Interpreter) {
-	funcName := fn.FunctionName
-
 	funcSymbol := Symbol{
-		Name: funcName,
-		Type: constants.FUNCTION_TYPE,
+		Name:     fn.FunctionName,
+		Category: constants.FUNCTION_CATEGORY,
+	}
+
+	// the function is stored in a slot of the enclosing record, same as a variable
+	i.DeclareSlot(&funcSymbol)
+
+	enclosingScope := i.CurrentScope
+
+	*fn.Symbol = i.scopeFunction(funcSymbol, fn.FormalParameters, fn.FunctionBlock, fn.ReturningValue, fn.ReturnTypeNode, enclosingScope.DefineSymbol)
+}
+
+/*
+	Scopes the parameters and the body of a function in a new scope with its own activation record.
+
+	define is called with the function's symbol before the body is scoped, so that the function can call
+	itself, and again with the finished symbol which is also returned
+*/
+func (i *Interpreter) scopeFunction(
+	funcSymbol Symbol,
+	params []FunctionParameters,
+	block, returningValue, returnTypeNode AbstractSyntaxTree,
+	define func(Symbol),
+) Symbol {
+	scopeName := funcSymbol.Name
+
+	if scopeName == "" {
+		scopeName = constants.FN
 	}
 
 	// used by the interpreter when executing the function
-	funcSymbol.FunctionBlock = fn.FunctionBlock
+	funcSymbol.FunctionBlock = block
+
+	if returnTypeNode != nil {
+		returnType, exists := i.CurrentScope.LookupType(returnTypeNode.GetToken().Value)
+
+		if !exists {
+			i.CurrentScope.Error(constants.ERROR_ID_NOT_FOUND, returnTypeNode.GetToken())
+		}
+
+		funcSymbol.ReturnType = returnType.Name
+	}
 
 	funcScope := ScopedSymbolsTable{
-		CurrentScopeName:  funcName,
+		CurrentScopeName:  scopeName,
 		CurrentScopeLevel: i.CurrentScope.CurrentScopeLevel + 1,
 		EnclosingScope:    i.CurrentScope,
+		Frame:             &FrameLayout{Level: i.CurrentScope.Frame.Level + 1},
 	}
 
 	funcScope.Init()
 	i.CurrentScope = &funcScope
 	defer i.ReleaseScope()
 
+	// the function body doesn't run where it's declared, so it doesn't change what's assigned around it
+	before := i.Assignments.snapshot()
+	enclosingFunctionLevel := i.Assignments.functionScopeLevel
+	i.Assignments.functionScopeLevel = funcScope.CurrentScopeLevel
+
+	defer func() {
+		i.Assignments.restore(before)
+		i.Assignments.functionScopeLevel = enclosingFunctionLevel
+	}()
+
 	// fmt.Println("Entering Scope, ", funcName)
 
 	// helpers.ColorPrint(
@@ -61,32 +115,105 @@ func (fn FunctionDeclaration) Scope(i *Interpreter) {
 	// 	"\nglobal scope ", funcScope.EnclosingScope
 	// )
 
-	for _, param := range fn.FormalParameters {
+	hasDefaults := false
+
+	for index, param := range params {
 		paramName := param.VariableNode.GetToken().Value
 
-		// this is going to be a built in type so it will definitely exist
 		paramType := param.TypeNode.GetToken().Value
 
+		if _, exists := i.CurrentScope.LookupType(paramType); !exists {
+			i.CurrentScope.Error(constants.ERROR_ID_NOT_FOUND, param.TypeNode.GetToken())
+		}
+
 		paramSymbol := Symbol{
 			Name: paramName,
 			Type: paramType,
 		}
 
+		// the arguments are matched to the parameters in order, so only the last ones can be left out
+		if param.Variadic {
+			if index != len(params)-1 {
+				errors.ShowError(
+					constants.SEMANTIC_ERROR,
+					constants.ERROR_WRONG_ARGUMENTS,
+					fmt.Sprintf("Only the last parameter can be variadic, '%s' isn't", paramName),
+					param.VariableNode.GetToken(),
+				)
+			}
+
+			// without arguments for it, the variadic parameter is an empty list
+			paramSymbol.Variadic = true
+			paramSymbol.Value = runtime.ListValue([]runtime.Value{})
+		} else if param.Default != nil {
+			hasDefaults = true
+			paramSymbol.Value = i.defaultValue(param, paramType)
+		} else if hasDefaults {
+			errors.ShowError(
+				constants.SEMANTIC_ERROR,
+				constants.ERROR_WRONG_ARGUMENTS,
+				fmt.Sprintf("Parameter '%s' needs a default value, it comes after a parameter with one", paramName),
+				param.VariableNode.GetToken(),
+			)
+		}
+
+		// the parameters take the first slots of the function's record, in order
+		i.DeclareSlot(&paramSymbol)
+
 		i.CurrentScope.DefineSymbol(paramSymbol)
 
 		// add all the parameters symbols
 		funcSymbol.ParamSymbols = append(funcSymbol.ParamSymbols, paramSymbol)
 	}
 
-	funcSymbol.ReturningValue = fn.ReturningValue
+	funcSymbol.Type = FunctionTypeOf(funcSymbol.ParamTypes(), funcSymbol.ReturnType)
+	funcSymbol.ReturningValue = returningValue
 
-	// we've already created a new scope, so need to add the funcSymbol to the enclosing scope
-	i.CurrentScope.EnclosingScope.DefineSymbol(funcSymbol)
+	// we've already created a new scope, so the function is defined in the enclosing scope
+	define(funcSymbol)
 
-	fn.FunctionBlock.Scope(i)
+	block.Scope(i)
+
+	if returningValue != nil {
+		if returnTypeNode != nil {
+			expectType(returningValue, funcSymbol.ReturnType)
+		}
+
+		returningValue.Scope(i)
+
+		// the returned expression can only be typed once everything in the function block is known
+		valueType := i.TypeOf(returningValue)
+
+		if returnTypeNode == nil {
+			funcSymbol.ReturnType = valueType
+		} else if !IsAssignable(funcSymbol.ReturnType, valueType) {
+			errors.ShowError(
+				constants.TYPE_ERROR,
+				constants.ERROR_TYPE_MISMATCH,
+				returnTypeMismatch(scopeName, funcSymbol.ReturnType, valueType),
+				returnTypeNode.GetToken(),
+			)
+		}
+
+		funcSymbol.ReturningValue = i.Optimize(returningValue)
+	} else if returnTypeNode != nil {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_TYPE_MISMATCH,
+			fmt.Sprintf("%s has to return a value of type %s", scopeName, funcSymbol.ReturnType),
+			returnTypeNode.GetToken(),
+		)
+	}
+
+	funcSymbol.Type = FunctionTypeOf(funcSymbol.ParamTypes(), funcSymbol.ReturnType)
+
+	// the interpreter runs the function from its symbol, so that's what gets optimized
+	funcSymbol.FunctionBlock = i.Optimize(block)
+	define(funcSymbol)
 
 	// fmt.Println("Exit Scope, ", funcName)
 
+	return funcSymbol
 }
 
 // function parameters
@@ -104,7 +231,7 @@ func (fn FunctionCall) GetToken() types.Token {
 }
 
 func (fn FunctionCall) Scope(i *Interpreter) {
-	_, exists := i.CurrentScope.LookupSymbol(fn.FunctionName, false)
+	funcSymbol, exists := i.CurrentScope.LookupSymbol(fn.FunctionName, false)
 
 	if !exists {
 		errors.ShowError(
@@ -115,7 +242,393 @@ func (fn FunctionCall) Scope(i *Interpreter) {
 		)
 	}
 
+	var argTypes []string
+
 	for _, paramNode := range fn.ActualParameters {
 		paramNode.Scope(i)
+		argTypes = append(argTypes, i.TypeOf(paramNode))
+	}
+
+	if funcSymbol.Native == nil {
+		if funcSymbol.Category != constants.FUNCTION_CATEGORY && !IsFunctionType(funcSymbol.Type) {
+			errors.ShowError(
+				constants.TYPE_ERROR,
+				constants.TYPE_ERROR,
+				fmt.Sprintf("'%s' is not a function, it's a %s", fn.FunctionName, funcSymbol.Type),
+				fn.Token,
+			)
+		}
+
+		// functions are called through the value in their slot, which can be a parameter or variable too
+		i.CheckAssigned(Variable{Token: fn.Token, Value: fn.FunctionName})
+		i.Resolve(funcSymbol, fn.Binding)
+		fn.Binding.Type = funcSymbol.Type
+
+		// the parameters of a declared function are known before its body is scoped, so this works for recursive calls too
+		if funcSymbol.Category == constants.FUNCTION_CATEGORY {
+			arrangeArguments(fn, funcSymbol.ParamSymbols, argTypes)
+			return
+		}
+
+		// the type of a function value doesn't have the names or defaults of its parameters
+		if fn.ArgumentNames != nil {
+			argumentsError(fn, fmt.Sprintf("'%s' is a function value, its arguments can't be named", fn.FunctionName))
+		}
+
+		paramTypes, _ := FunctionTypeParts(funcSymbol.Type)
+		arrangeArguments(fn, paramSymbolsOf(paramTypes), argTypes)
+
+		return
+	}
+
+	if fn.ArgumentNames != nil {
+		argTypes = checkNativeNamedArguments(fn, funcSymbol.Native, argTypes)
+	}
+
+	if fn.Spread {
+		argumentsError(fn, fmt.Sprintf("Built in function %s doesn't take a spread list", fn.FunctionName))
+	}
+
+	fn.Binding.Native = funcSymbol.Native
+
+	if funcSymbol.Native.ParamTypes != nil {
+		checkNativeParamTypes(fn, funcSymbol.Native.ParamTypes, argTypes)
+	}
+
+	if funcSymbol.Native.Check != nil {
+		funcSymbol.Native.Check(i, fn, argTypes)
+	}
+}
+
+// the value of a parameter's default, which has to be known before the program runs like a constant's
+func (i *Interpreter) defaultValue(param FunctionParameters, paramType string) runtime.Value {
+	paramName := param.VariableNode.GetToken().Value
+
+	param.Default.Scope(i)
+
+	if defaultType := i.TypeOf(param.Default); !IsAssignable(paramType, defaultType) {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_TYPE_MISMATCH,
+			fmt.Sprintf("The default value of '%s' of type %s is of type %s", paramName, paramType, defaultType),
+			param.VariableNode.GetToken(),
+		)
+	}
+
+	value, ok := literalValue(i.FoldConstants(param.Default))
+
+	if !ok {
+		errors.ShowError(
+			constants.SEMANTIC_ERROR,
+			constants.ERROR_NOT_CONSTANT,
+			fmt.Sprintf("The default value of '%s' has to be known before the program runs", paramName),
+			param.VariableNode.GetToken(),
+		)
+	}
+
+	// ratio: float = 1
+	if value.Kind == runtime.INT && NonOptionalType(paramType) == constants.FLOAT_TYPE {
+		value = runtime.FloatValue(float32(value.Int))
+	}
+
+	return value
+}
+
+/*
+	Matches the arguments of a call to the parameters of the function, positional arguments first and then
+	the named ones. The parameters left out take their defaults, and the positional arguments after the
+	others go in the list of a variadic parameter, unless a list is spread into it.
+
+	If the arguments aren't simply in the order of the parameters, the binding of the call gets where each
+	parameter's argument is
+*/
+func arrangeArguments(fn FunctionCall, params []Symbol, argTypes []string) {
+	arguments := make([]int, len(params))
+	arranged := false
+
+	// the parameters before a variadic one
+	fixed := len(params)
+	variadic := fixed > 0 && params[fixed-1].Variadic
+
+	if variadic {
+		fixed--
+	}
+
+	for index := range arguments {
+		arguments[index] = -1
+	}
+
+	for index := range fn.ActualParameters {
+		name := ""
+
+		if fn.ArgumentNames != nil {
+			name = fn.ArgumentNames[index]
+		}
+
+		spread := fn.Spread && index == len(fn.ActualParameters)-1
+
+		if name == "" {
+			if index > 0 && fn.ArgumentNames != nil && fn.ArgumentNames[index-1] != "" {
+				argumentsError(fn, fmt.Sprintf("Argument %d of %s comes after a named argument, so it has to be named too", index+1, fn.FunctionName))
+			}
+
+			switch {
+			case spread && !variadic:
+				argumentsError(fn, fmt.Sprintf("%s isn't variadic, a list can't be spread into it", fn.FunctionName))
+
+			// sum(xs...) is the same as passing xs as the variadic parameter
+			case spread && index != fixed:
+				argumentsError(fn, fmt.Sprintf("%s expects %d arguments before the spread list, got %d", fn.FunctionName, fixed, index))
+
+			case spread:
+				arguments[fixed] = index
+
+			case index >= fixed && variadic:
+				fn.Binding.Rest = append(fn.Binding.Rest, index)
+				arranged = true
+
+			case index >= fixed:
+				errors.ShowError(
+					constants.SEMANTIC_ERROR,
+					constants.ERROR_WRONG_ARGUMENTS,
+					fmt.Sprintf("%s expects %d arguments, got %d", fn.FunctionName, len(params), len(fn.ActualParameters)),
+					fn.Token,
+				)
+
+			default:
+				arguments[index] = index
+			}
+
+			continue
+		}
+
+		if spread {
+			argumentsError(fn, fmt.Sprintf("The list spread into %s can't be named", fn.FunctionName))
+		}
+
+		param := -1
+
+		for paramIndex, paramSymbol := range params {
+			if paramSymbol.Name == name {
+				param = paramIndex
+			}
+		}
+
+		if param < 0 {
+			argumentsError(fn, fmt.Sprintf("%s has no parameter named '%s'", fn.FunctionName, name))
+		}
+
+		if params[param].Variadic {
+			argumentsError(fn, fmt.Sprintf("'%s' of %s is variadic, its arguments can't be named", name, fn.FunctionName))
+		}
+
+		if arguments[param] >= 0 {
+			argumentsError(fn, fmt.Sprintf("The argument for '%s' of %s is given more than once", name, fn.FunctionName))
+		}
+
+		arguments[param] = index
+		arranged = arranged || param != index
+	}
+
+	for paramIndex, param := range params {
+		argument := arguments[paramIndex]
+
+		if argument < 0 {
+			if param.Value.IsNil() {
+				message := fmt.Sprintf("%s is missing the argument for '%s'", fn.FunctionName, param.Name)
+
+				if fn.ArgumentNames == nil && variadic {
+					message = fmt.Sprintf("%s expects at least %d arguments, got %d", fn.FunctionName, requiredParams(params), len(fn.ActualParameters))
+				} else if fn.ArgumentNames == nil && params[len(params)-1].Value.IsNil() {
+					message = fmt.Sprintf("%s expects %d arguments, got %d", fn.FunctionName, len(params), len(fn.ActualParameters))
+				}
+
+				errors.ShowError(constants.SEMANTIC_ERROR, constants.ERROR_WRONG_ARGUMENTS, message, fn.Token)
+			}
+
+			arranged = true
+			continue
+		}
+
+		checkArgument(fn, argument, param.Type, argTypes[argument])
+	}
+
+	// the elements of the variadic parameter's list
+	for _, argument := range fn.Binding.Rest {
+		checkArgument(fn, argument, ListElementType(params[fixed].Type), argTypes[argument])
+	}
+
+	if arranged {
+		fn.Binding.Arguments = arguments
+
+		for _, param := range params {
+			fn.Binding.Defaults = append(fn.Binding.Defaults, param.Value)
+		}
+	}
+}
+
+// the number of parameters without a default
+func requiredParams(params []Symbol) int {
+	required := 0
+
+	for _, param := range params {
+		if param.Value.IsNil() {
+			required++
+		}
+	}
+
+	return required
+}
+
+func checkArgument(fn FunctionCall, argument int, paramType, argType string) {
+	if !IsAssignable(paramType, argType) {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_WRONG_ARGUMENTS,
+			fmt.Sprintf("%s expects argument %d to be %s, got %s", fn.FunctionName, argument+1, paramType, argType),
+			fn.Token,
+		)
+	}
+}
+
+func argumentsError(fn FunctionCall, message string) {
+	errors.ShowError(constants.SEMANTIC_ERROR, constants.ERROR_WRONG_ARGUMENTS, message, fn.Token)
+}
+
+/*
+	Puts the evaluated arguments of a call in the order of the function's parameters, with the defaults filled
+	in and the rest of the arguments collected into the list of a variadic parameter
+*/
+func (b *Binding) ArrangeArguments(args []runtime.Value) []runtime.Value {
+	if b.Arguments == nil {
+		return args
+	}
+
+	arranged := make([]runtime.Value, len(b.Arguments))
+
+	for param, argument := range b.Arguments {
+		if argument < 0 {
+			arranged[param] = b.Defaults[param]
+		} else {
+			arranged[param] = args[argument]
+		}
+	}
+
+	if b.Rest != nil {
+		rest := make([]runtime.Value, 0, len(b.Rest))
+
+		for _, argument := range b.Rest {
+			rest = append(rest, args[argument])
+		}
+
+		arranged[len(arranged)-1] = runtime.ListValue(rest)
+	}
+
+	return arranged
+}
+
+// the parameter the argument at index is passed to, for the arguments of a variadic parameter that's the last one
+func (b *Binding) ParameterOf(index int) int {
+	for param, argument := range b.Arguments {
+		if argument == index {
+			return param
+		}
+	}
+
+	for _, argument := range b.Rest {
+		if argument == index {
+			return len(b.Arguments) - 1
+		}
+	}
+
+	return index
+}
+
+// checks the named arguments of a call to a built in function, and returns the types of the positional ones
+func checkNativeNamedArguments(fn FunctionCall, native *NativeFunction, argTypes []string) []string {
+	var positional []string
+
+	for index, name := range fn.ArgumentNames {
+		if name == "" {
+			if index > 0 && fn.ArgumentNames[index-1] != "" {
+				argumentsError(fn, fmt.Sprintf("Argument %d of %s comes after a named argument, so it has to be named too", index+1, fn.FunctionName))
+			}
+
+			positional = append(positional, argTypes[index])
+			continue
+		}
+
+		paramType, exists := native.NamedParams[name]
+
+		if !exists {
+			argumentsError(fn, fmt.Sprintf("Built in function %s has no argument named '%s'", fn.FunctionName, name))
+		}
+
+		for _, previous := range fn.ArgumentNames[:index] {
+			if previous == name {
+				argumentsError(fn, fmt.Sprintf("The argument for '%s' of %s is given more than once", name, fn.FunctionName))
+			}
+		}
+
+		if argType := argTypes[index]; !IsAssignable(paramType, argType) {
+			errors.ShowError(
+				constants.TYPE_ERROR,
+				constants.ERROR_WRONG_ARGUMENTS,
+				fmt.Sprintf("%s expects '%s' to be %s, got %s", fn.FunctionName, name, paramType, argType),
+				fn.Token,
+			)
+		}
+	}
+
+	return positional
+}
+
+// splits the evaluated arguments of a call to a built in function into the positional and the named ones
+func nativeArguments(f FunctionCall, args []runtime.Value) ([]runtime.Value, map[string]runtime.Value) {
+	if f.ArgumentNames == nil {
+		return args, nil
+	}
+
+	var positional []runtime.Value
+	named := map[string]runtime.Value{}
+
+	for index, arg := range args {
+		if name := f.ArgumentNames[index]; name != "" {
+			named[name] = arg
+		} else {
+			positional = append(positional, arg)
+		}
+	}
+
+	return positional, named
+}
+
+func checkNativeParamTypes(fn FunctionCall, paramTypes []string, argTypes []string) {
+	if len(paramTypes) != len(argTypes) {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_WRONG_ARGUMENTS,
+			fmt.Sprintf("%s expects %d arguments, got %d", fn.FunctionName, len(paramTypes), len(argTypes)),
+			fn.Token,
+		)
+	}
+
+	for index, paramType := range paramTypes {
+		argType := argTypes[index]
+
+		if argType == "" || argType == paramType {
+			continue
+		}
+
+		if paramType == constants.NUMBER_TYPE && (argType == constants.INTEGER_TYPE || argType == constants.FLOAT_TYPE) {
+			continue
+		}
+
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_WRONG_ARGUMENTS,
+			fmt.Sprintf("%s expects argument %d to be %s, got %s", fn.FunctionName, index+1, paramType, argType),
+			fn.Token,
+		)
 	}
 }
@@ -0,0 +1,141 @@
+package interpreter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"programminglang/constants"
+	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
+)
+
+func (i *Interpreter) GetStdin() *bufio.Reader {
+	if i.stdinReader != nil {
+		return i.stdinReader
+	}
+
+	var stdin io.Reader = os.Stdin
+
+	if i.Stdin != nil {
+		stdin = i.Stdin
+	}
+
+	// don't wrap a reader that's already buffered, ex - the REPL shares its reader with the scripts
+	if reader, ok := stdin.(*bufio.Reader); ok {
+		i.stdinReader = reader
+	} else {
+		i.stdinReader = bufio.NewReader(stdin)
+	}
+
+	return i.stdinReader
+}
+
+// reads a line from stdin without the line terminator, returns an empty string at the end of the input
+func (i *Interpreter) readLine(f FunctionCall) string {
+	line, err := i.GetStdin().ReadString('\n')
+
+	if err != nil && err != io.EOF {
+		inputError(f, err)
+	}
+
+	line = strings.TrimSuffix(line, "\n")
+	line = strings.TrimSuffix(line, "\r")
+
+	return line
+}
+
+// reported at the call which was reading
+func inputError(f FunctionCall, err error) {
+	errors.ShowError(
+		constants.RUNTIME_ERROR,
+		constants.ERROR_INPUT,
+		fmt.Sprintf("%s: failed to read from stdin: %s", f.FunctionName, err),
+		f.Token,
+	)
+}
+
+// input(prompt) prints the prompt and reads a line
+func nativeInput(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	for _, arg := range args {
+		fmt.Fprint(i.GetStdout(), arg)
+	}
+
+	return runtime.StringValue(i.readLine(f))
+}
+
+func nativeReadLine(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	return runtime.StringValue(i.readLine(f))
+}
+
+// read_all() reads everything up to the end of the input
+func nativeReadAll(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	data, err := ioutil.ReadAll(i.GetStdin())
+
+	if err != nil {
+		inputError(f, err)
+	}
+
+	return runtime.StringValue(string(data))
+}
+
+// eof() is true once all of the input has been read
+func nativeEndOfInput(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	_, err := i.GetStdin().Peek(1)
+
+	return runtime.BoolValue(err != nil)
+}
+
+// parse_int(text) converts text to an int, surrounding whitespace is ignored
+func nativeParseInt(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	text := parseArgument(f, args)
+
+	value, err := strconv.Atoi(strings.TrimSpace(text))
+
+	if err != nil {
+		parseError(f, text, constants.INTEGER_TYPE)
+	}
+
+	return runtime.IntValue(value)
+}
+
+// parse_float(text) converts text to a float, surrounding whitespace is ignored
+func nativeParseFloat(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	text := parseArgument(f, args)
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(text), 32)
+
+	if err != nil {
+		parseError(f, text, constants.FLOAT_TYPE)
+	}
+
+	return runtime.FloatValue(float32(value))
+}
+
+func parseArgument(f FunctionCall, args []runtime.Value) string {
+	if len(args) == 1 && args[0].Kind == runtime.STRING {
+		return args[0].Str
+	}
+
+	errors.ShowError(
+		constants.RUNTIME_ERROR,
+		constants.ERROR_INVALID_ARGUMENT,
+		fmt.Sprintf("%s expects a single string", f.FunctionName),
+		f.Token,
+	)
+
+	return ""
+}
+
+func parseError(f FunctionCall, text string, typeName string) {
+	errors.ShowError(
+		constants.RUNTIME_ERROR,
+		constants.ERROR_INVALID_ARGUMENT,
+		fmt.Sprintf("%s: '%s' is not a valid %s", f.FunctionName, text, typeName),
+		f.Token,
+	)
+}
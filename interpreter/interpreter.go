@@ -1,7 +1,14 @@
 package interpreter
 
 import (
+	"bufio"
+	"io"
+
+	"programminglang/constants"
+	"programminglang/helpers"
 	"programminglang/interpreter/callstack"
+	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
 )
 
 type Interpreter struct {
@@ -9,6 +16,32 @@ type Interpreter struct {
 	CallStack          callstack.CallStack
 	ScopedSymbolsTable *ScopedSymbolsTable
 	CurrentScope       *ScopedSymbolsTable
+	Assignments        AssignmentAnalysis
+
+	Args []string          // returned by args(), the arguments passed after the script path
+	Env  map[string]string // looked up by env(name), if nil the process environment is used
+
+	Stdin     io.Reader // read by input(), read_line() and read_all(), defaults to os.Stdin
+	Stdout    io.Writer // where output() writes, defaults to os.Stdout
+	Stderr    io.Writer // where errors are reported, defaults to os.Stderr
+	ColorMode string    // constants.COLOR_AUTO (default), COLOR_ALWAYS or COLOR_NEVER
+
+	// constants.OPT_LEVEL_NONE only replaces constants with their values, OPT_LEVEL_FULL also folds and simplifies expressions
+	OptLevel int
+
+	Engine string // constants.ENGINE_TREE (default) walks the tree, ENGINE_VM compiles it to bytecode first
+
+	// the directory the file functions are confined to, file access is disabled when it's empty
+	FileRoot string
+
+	Exited   bool // set when the script calls exit(code) or stops because of an error
+	ExitCode int
+	Err      errors.ErrorInterface // the error the script stopped with, if any
+
+	Warnings []string // found while scoping, ex - a match over an enum missing some of its members
+
+	stdinReader *bufio.Reader
+	callDepth   int // the function calls the tree walker is running
 }
 
 func (i *Interpreter) Init(text string, printToken bool) {
@@ -16,6 +49,13 @@ func (i *Interpreter) Init(text string, printToken bool) {
 	i.TextParser.Init(text, printToken)
 
 	i.CallStack = callstack.CallStack{}
+	i.Assignments.Init()
+	i.callDepth = 0
+
+	i.Exited = false
+	i.ExitCode = 0
+	i.Err = nil
+	i.Warnings = nil
 }
 
 func (i *Interpreter) InitConcrete() {
@@ -26,10 +66,10 @@ func (i *Interpreter) InitConcrete() {
 	i.CurrentScope.Init()
 }
 
-func (i *Interpreter) Visit(node AbstractSyntaxTree) interface{} {
+func (i *Interpreter) Visit(node AbstractSyntaxTree) runtime.Value {
 	// helpers.ColorPrint(constants.LightGreen, 1, "node ", constants.SpewPrinter.Sdump(node))
 
-	var result interface{}
+	var result runtime.Value
 
 	if in, ok := node.(IntegerNumber); ok {
 		// node is a Number struct, which is the base case
@@ -37,12 +77,15 @@ func (i *Interpreter) Visit(node AbstractSyntaxTree) interface{} {
 
 	} else if f, ok := node.(FloatNumber); ok {
 		// node is a Number struct, which is the base case
-		result = f.Token.FloatValue
+		result = runtime.FloatValue(f.Token.FloatValue)
 
 	} else if s, ok := node.(String); ok {
-		result = s.Token.Value
+		result = runtime.StringValue(s.Token.Value)
 	} else if b, ok := node.(Boolean); ok {
-		result = b.Value
+		result = runtime.BoolValue(b.Value)
+
+	} else if _, ok := node.(NoneLiteral); ok {
+		result = runtime.None
 
 	} else if u, ok := node.(UnaryOperationNode); ok {
 		result = i.EvaluateUnaryOperator(u)
@@ -74,16 +117,65 @@ func (i *Interpreter) Visit(node AbstractSyntaxTree) interface{} {
 	} else if l, ok := node.(LogicalNode); ok {
 		result = i.EvaluateLogicalStatement(l)
 
+	} else if cn, ok := node.(CoalesceNode); ok {
+		result = i.EvaluateCoalesceNode(cn)
+
+	} else if em, ok := node.(EnumMember); ok {
+		result = em.Binding.Value
+
+	} else if efo, ok := node.(EnumFromOrdinal); ok {
+		result = i.EvaluateEnumFromOrdinal(efo)
+
+	} else if ce, ok := node.(ConditionalExpression); ok {
+		result = i.EvaluateConditionalExpression(ce)
+
 	} else if c, ok := node.(ConditionalStatement); ok {
 		result = i.EvaluateConditionalStatement(c)
 
+	} else if ms, ok := node.(MatchStatement); ok {
+		result = i.EvaluateMatchStatement(ms)
+
 	} else if l, ok := node.(RangeLoop); ok {
 		result = i.EvaluateRangeLoop(l)
+
+	} else if in, ok := node.(IndexNode); ok {
+		result = i.EvaluateIndexNode(in)
+
+	} else if tc, ok := node.(TypeConversion); ok {
+		result = i.EvaluateTypeConversion(tc)
+
+	} else if fn, ok := node.(FunctionDeclaration); ok {
+		result = i.EvaluateFunctionDeclaration(fn)
+
+	} else if fl, ok := node.(FunctionLiteral); ok {
+		result = i.EvaluateFunctionLiteral(fl)
+
+	} else if ll, ok := node.(ListLiteral); ok {
+		result = i.EvaluateListLiteral(ll)
+
+	} else if tl, ok := node.(TupleLiteral); ok {
+		result = i.EvaluateTupleLiteral(tl)
+
+	} else if da, ok := node.(DestructuringAssignment); ok {
+		result = i.EvaluateDestructuringAssignment(da)
 	}
 
 	return result
 }
 
+// creates a new scope nested in the current one, release it with ReleaseScope
+func (i *Interpreter) EnterScope(scopeName string) {
+	scope := ScopedSymbolsTable{
+		CurrentScopeName:  scopeName,
+		CurrentScopeLevel: i.CurrentScope.CurrentScopeLevel + 1,
+		EnclosingScope:    i.CurrentScope,
+		Frame:             i.CurrentScope.Frame,
+	}
+
+	scope.Init()
+	i.CurrentScope = &scope
+}
+
 // changes the interpreter's current enclosing scope to its parent's EnclosingScope
 func (i *Interpreter) ReleaseScope() {
 	// helpers.ColorPrint(
@@ -94,12 +186,36 @@ func (i *Interpreter) ReleaseScope() {
 	i.CurrentScope = i.CurrentScope.EnclosingScope
 }
 
-func (i *Interpreter) Interpret() interface{} {
+func (i *Interpreter) Interpret() (result runtime.Value) {
+	// exit(code) and errors unwind the whole evaluation, stop here and record the exit code
+	defer func() {
+		if r := recover(); r != nil {
+			if signal, ok := r.(exitSignal); ok {
+				i.ExitCode = signal.code
+			} else if err, ok := r.(errors.ErrorInterface); ok {
+				i.Err = err
+				i.ExitCode = 1
+
+				helpers.ColorFprint(i.GetStderr(), i.UseColor(i.GetStderr()), constants.Red, "\n", err.Error(), "\n")
+			} else {
+				panic(r)
+			}
+
+			i.Exited = true
+			result = runtime.Nil
+		}
+	}()
+
 	tree := i.TextParser.Parse()
 
 	// helpers.ColorPrint(constants.LightGreen, 1, 1, constants.SpewPrinter.Sdump(tree))
 
 	tree.Scope(i)
+	tree = i.Optimize(tree)
+
+	if i.Engine == constants.ENGINE_VM {
+		return i.RunBytecode(i.Compile(tree))
+	}
 
 	// helpers.ColorPrint(constants.LightGreen, 1, 1, constants.SpewPrinter.Sdump(i.CurrentScope))
 
@@ -3,6 +3,7 @@ package interpreter
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"unicode"
 
 	"programminglang/constants"
@@ -102,7 +103,8 @@ func (lex *LexicalAnalyzer) ConstructNumber() types.Token {
 
 	// helpers.ColorPrint(constants.LightCyan, 1, "integerPart = ", integerPart)
 
-	if string(lex.CurrentChar) == constants.DOT_SYMBOL {
+	// a number followed by a spread or a range isn't a float, ex - sum(1...) is a type error and 1..10 a range
+	if string(lex.CurrentChar) == constants.DOT_SYMBOL && !strings.HasPrefix(lex.Text[lex.Position:], constants.RANGE_SYMBOL) {
 		// is a floating point number
 		s := string(lex.CurrentChar) // the dot
 
@@ -155,6 +157,9 @@ func (lex *LexicalAnalyzer) Identifier() types.Token {
 
 	if token, ok := constants.RESERVED[identifier]; ok {
 		// is a reserved keyword
+		token.LineNumber = lex.LineNumber
+		token.Column = lex.Column
+
 		return token
 	}
 
@@ -169,6 +174,18 @@ func (lex *LexicalAnalyzer) ConstructString(quote string) types.Token {
 	currentChar := string(lex.Text[lex.Position])
 
 	for !lex.EndOfInput && currentChar != quote {
+		// escape sequences, ex - "\n"
+		if currentChar == constants.BACKSLASH_SYMBOL && lex.Peek() != -1 {
+			lex.Advance()
+			currentChar = string(lex.Text[lex.Position])
+
+			if escaped, ok := constants.ESCAPE_SEQUENCES[currentChar]; ok {
+				currentChar = escaped
+			} else {
+				str += constants.BACKSLASH_SYMBOL
+			}
+		}
+
 		str += currentChar
 
 		lex.Advance()
@@ -207,6 +224,13 @@ func (lex *LexicalAnalyzer) GetNextToken() types.Token {
 			return lex.ConstructNumber()
 		}
 
+		// the pattern of a match arm which matches anything, ex - _ => { }
+		if charToString == constants.WILDCARD_SYMBOL && (lex.Peek() == -1 || !helpers.IsAlphaNum(lex.Text[lex.Peek()])) {
+			token := lex.GetToken(constants.WILDCARD, constants.WILDCARD_SYMBOL)
+			lex.Advance()
+			return token
+		}
+
 		// starts with a letter, is an identifier
 		if unicode.IsLetter(rune(lex.CurrentChar)) {
 			identifier := lex.Identifier()
@@ -253,6 +277,52 @@ func (lex *LexicalAnalyzer) GetNextToken() types.Token {
 			return token
 		}
 
+		// variadic parameters and spread arguments, ex - nums: ...int and sum(xs...)
+		if strings.HasPrefix(lex.Text[lex.Position:], constants.ELLIPSIS_SYMBOL) {
+			token := lex.GetToken(constants.ELLIPSIS, constants.ELLIPSIS_SYMBOL)
+
+			for range constants.ELLIPSIS_SYMBOL {
+				lex.Advance()
+			}
+
+			return token
+		}
+
+		// the range patterns of match statements, ex - 1..10
+		if strings.HasPrefix(lex.Text[lex.Position:], constants.RANGE_SYMBOL) {
+			token := lex.GetToken(constants.RANGE, constants.RANGE_SYMBOL)
+
+			lex.Advance()
+			lex.Advance()
+
+			return token
+		}
+
+		// the members of enums, ex - Color.Red
+		if charToString == constants.DOT_SYMBOL {
+			token := lex.GetToken(constants.DOT, constants.DOT_SYMBOL)
+			lex.Advance()
+			return token
+		}
+
+		// optional types and the default of an optional, ex - let x: int? and x ?? 0
+		if charToString == constants.QUESTION_SYMBOL {
+			peekPos := lex.Peek()
+
+			if peekPos != -1 && string(lex.Text[peekPos]) == constants.QUESTION_SYMBOL {
+				token := lex.GetToken(constants.COALESCE, constants.COALESCE_SYMBOL)
+
+				lex.Advance()
+				lex.Advance()
+
+				return token
+			}
+
+			token := lex.GetToken(constants.QUESTION, constants.QUESTION_SYMBOL)
+			lex.Advance()
+			return token
+		}
+
 		if charToString == constants.GREATER_THAN_SYMBOL {
 			// need to peek for an equal sign
 			peekPos := lex.Peek()
@@ -311,7 +381,22 @@ func (lex *LexicalAnalyzer) GetNextToken() types.Token {
 
 					return token
 				}
+
+				// the arms of match statements, ex - 1 => { }
+				if string(lex.Text[peekPos]) == constants.GREATER_THAN_SYMBOL {
+					token := lex.GetToken(constants.FAT_ARROW, constants.FAT_ARROW_SYMBOL)
+
+					lex.Advance()
+					lex.Advance()
+
+					return token
+				}
 			}
+
+			// just an equal sign, ex - let x = 5;
+			token := lex.GetToken(constants.EQUAL, constants.EQUAL_SYMBOL)
+			lex.Advance()
+			return token
 		}
 
 		if charToString == constants.EXCLAMATION_SYMBOL {
@@ -338,6 +423,18 @@ func (lex *LexicalAnalyzer) GetNextToken() types.Token {
 		}
 
 		if charToString == constants.OPERANDS[constants.MINUS] {
+			// need to peek for the arrow of a return type, ex - fn(int) -> int
+			peekPos := lex.Peek()
+
+			if peekPos != -1 && string(lex.Text[peekPos]) == constants.GREATER_THAN_SYMBOL {
+				token := lex.GetToken(constants.ARROW, constants.ARROW_SYMBOL)
+
+				lex.Advance()
+				lex.Advance()
+
+				return token
+			}
+
 			token := lex.GetToken(constants.MINUS, constants.OPERANDS[constants.MINUS])
 			lex.Advance()
 			return token
@@ -414,6 +511,18 @@ func (lex *LexicalAnalyzer) GetNextToken() types.Token {
 			return token
 		}
 
+		if charToString == constants.LSQUARE_SYMBOL {
+			token := lex.GetToken(constants.LSQUARE, constants.LSQUARE_SYMBOL)
+			lex.Advance()
+			return token
+		}
+
+		if charToString == constants.RSQUARE_SYMBOL {
+			token := lex.GetToken(constants.RSQUARE, constants.RSQUARE_SYMBOL)
+			lex.Advance()
+			return token
+		}
+
 		if charToString == constants.COMMA_SYMBOL {
 			token := lex.GetToken(constants.COMMA, constants.COMMA_SYMBOL)
 			lex.Advance()
@@ -0,0 +1,163 @@
+package interpreter
+
+import (
+	"fmt"
+	"strings"
+
+	"programminglang/constants"
+	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
+	"programminglang/types"
+)
+
+// indexing into a list or a string. Ex - args()[0]
+type IndexNode struct {
+	Token types.Token // the LSQUARE token
+	Left  AbstractSyntaxTree
+	Index AbstractSyntaxTree
+}
+
+func (in IndexNode) GetToken() types.Token {
+	return in.Token
+}
+
+func (in IndexNode) Scope(i *Interpreter) {
+	in.Left.Scope(i)
+	in.Index.Scope(i)
+
+	i.checkNotNone(in.Left, in.Token)
+	i.checkNotNone(in.Index, in.Token)
+}
+
+// a list written out in the program, ex - [1, 2, 3]
+type ListLiteral struct {
+	Token    types.Token // the LSQUARE token
+	Elements []AbstractSyntaxTree
+	Binding  *Binding // Type is the type of the variable, parameter or return value the list is stored in, if known
+}
+
+func (ll ListLiteral) GetToken() types.Token {
+	return ll.Token
+}
+
+/*
+	Every element has to be of the element type of where the list is stored, ex - let xs: [int?] = [1, none];
+	Otherwise they have to be of the type of the first one. An empty list can be stored in any list variable
+*/
+func (ll ListLiteral) Scope(i *Interpreter) {
+	var elementType string
+
+	expected := ll.Binding.Type != ""
+
+	if expected {
+		elementType = ListElementType(ll.Binding.Type)
+	}
+
+	for index, element := range ll.Elements {
+		if expected {
+			expectType(element, elementType)
+		}
+
+		element.Scope(i)
+
+		if index == 0 && !expected {
+			elementType = i.TypeOf(element)
+			continue
+		}
+
+		if valueType := i.TypeOf(element); elementType != "" && !IsAssignable(elementType, valueType) {
+			errors.ShowError(
+				constants.TYPE_ERROR,
+				constants.ERROR_TYPE_MISMATCH,
+				fmt.Sprintf("Cannot add a value of type %s to a list of %s", valueType, elementType),
+				element.GetToken(),
+			)
+		}
+	}
+}
+
+func (i *Interpreter) EvaluateListLiteral(ll ListLiteral) runtime.Value {
+	elements := []runtime.Value{}
+
+	for _, element := range ll.Elements {
+		elements = append(elements, i.Visit(element))
+	}
+
+	// ints can be elements of a list of floats
+	if ll.holdsFloats() {
+		return toFloats(runtime.ListValue(elements))
+	}
+
+	return runtime.ListValue(elements)
+}
+
+// whether the list is stored where its elements are floats, ex - let xs: [float] = [1, 2.5];
+func (ll ListLiteral) holdsFloats() bool {
+	return ll.Binding.Type != "" && NonOptionalType(ListElementType(ll.Binding.Type)) == constants.FLOAT_TYPE
+}
+
+// a list literal takes the type of where it's stored, so its elements are checked against the element type of that
+func expectType(node AbstractSyntaxTree, typeName string) {
+	if ll, ok := node.(ListLiteral); ok && IsListType(typeName) {
+		ll.Binding.Type = typeName
+	}
+}
+
+// a list literal added to a list takes the type of that list, ex - scores + [7, none] where scores is an [int?]
+func (i *Interpreter) expectConcatenated(b BinaryOperationNode) {
+	if leftType := i.TypeOf(b.Left); b.Operation.Type == constants.PLUS && leftType != ListTypeOf("") {
+		expectType(b.Right, leftType)
+	}
+}
+
+/*
+	The elements of the right list have to fit in the left one, ex - [1] + ["a"] is an error.
+	An empty list on the left takes the type of the right one
+*/
+func (i *Interpreter) checkConcatenation(b BinaryOperationNode) {
+	leftType, rightType := i.TypeOf(b.Left), i.TypeOf(b.Right)
+
+	if b.Operation.Type != constants.PLUS || !IsListType(leftType) || !IsListType(rightType) {
+		return
+	}
+
+	if leftType == ListTypeOf("") || IsAssignable(leftType, rightType) {
+		return
+	}
+
+	errors.ShowError(
+		constants.TYPE_ERROR,
+		constants.ERROR_TYPE_MISMATCH,
+		fmt.Sprintf("Cannot add a list of type %s to a list of type %s", rightType, leftType),
+		b.Operation,
+	)
+}
+
+// returns the name of the type of a list holding elements of elementType. Ex - int -> [int]
+func ListTypeOf(elementType string) string {
+	return constants.LSQUARE_SYMBOL + elementType + constants.RSQUARE_SYMBOL
+}
+
+func IsListType(typeName string) bool {
+	return strings.HasPrefix(typeName, constants.LSQUARE_SYMBOL) && strings.HasSuffix(typeName, constants.RSQUARE_SYMBOL)
+}
+
+// returns the element type of a list type. Ex - [int] -> int
+func ListElementType(typeName string) string {
+	return typeName[1 : len(typeName)-1]
+}
+
+// a copy of the list with its ints converted to floats, for the list of a variadic float parameter
+func toFloats(list runtime.Value) runtime.Value {
+	floats := make([]runtime.Value, len(list.List))
+
+	for index, element := range list.List {
+		if element.Kind == runtime.INT {
+			element = runtime.FloatValue(float32(element.Int))
+		}
+
+		floats[index] = element
+	}
+
+	return runtime.ListValue(floats)
+}
@@ -1,6 +1,9 @@
 package interpreter
 
-import "programminglang/types"
+import (
+	"programminglang/constants"
+	"programminglang/types"
+)
 
 type LogicalNode struct {
 	Left            AbstractSyntaxTree // comparison node
@@ -12,4 +15,20 @@ func (cn LogicalNode) GetToken() types.Token {
 	return cn.LogicalOperator
 }
 
-func (fn LogicalNode) Scope(i *Interpreter) {}
+/*
+	The right operand only runs when the left one doesn't decide the result, so it's narrowed by the left one
+	being true for an and and false for an or. Ex - x in x != none and x > 1, and in x == none or x > 1
+*/
+func (fn LogicalNode) Scope(i *Interpreter) {
+	fn.Left.Scope(i)
+
+	i.EnterScope(fn.LogicalOperator.Value)
+	i.narrow(i.narrowedBy(fn.Left, fn.LogicalOperator.Type == constants.AND), fn.Right)
+
+	fn.Right.Scope(i)
+
+	i.ReleaseScope()
+
+	i.checkNotNone(fn.Left, fn.LogicalOperator)
+	i.checkNotNone(fn.Right, fn.LogicalOperator)
+}
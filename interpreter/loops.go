@@ -1,6 +1,9 @@
 package interpreter
 
-import "programminglang/types"
+import (
+	"programminglang/constants"
+	"programminglang/types"
+)
 
 // loops that range over some values. Ex - loop from 1 to 10 with id
 type RangeLoop struct {
@@ -14,4 +17,30 @@ func (rl RangeLoop) GetToken() types.Token {
 	return rl.IdentifierToken
 }
 
-func (rl RangeLoop) Scope(_ *Interpreter) {}
+func (rl RangeLoop) Scope(i *Interpreter) {
+	rl.Low.Scope(i)
+	rl.High.Scope(i)
+
+	i.checkNotNone(rl.Low, rl.IdentifierToken)
+	i.checkNotNone(rl.High, rl.IdentifierToken)
+
+	i.EnterFrame(constants.AR_LOOP)
+	defer i.ReleaseScope()
+
+	// the iterator is the first variable of the loop's record, so it's always in slot 0
+	iterator := Symbol{
+		Name: rl.IdentifierToken.Value,
+		Type: constants.INTEGER_TYPE,
+	}
+
+	i.DeclareSlot(&iterator)
+	i.CurrentScope.DefineSymbol(iterator)
+
+	// the body might not run at all, so nothing assigned in it is definitely assigned after the loop
+	before := i.Assignments.snapshot()
+	i.MarkPossiblyAssignedIn(rl.Block)
+
+	rl.Block.Scope(i)
+
+	i.Assignments.restore(before)
+}
@@ -0,0 +1,348 @@
+package interpreter
+
+import (
+	"fmt"
+	"strings"
+
+	"programminglang/constants"
+	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
+	"programminglang/types"
+)
+
+// runs the block of the first arm with a pattern matching the value. Ex - match x { 1, 2 => { } _ => { } }
+type MatchStatement struct {
+	Token   types.Token // the MATCH token
+	Subject AbstractSyntaxTree
+	Arms    []MatchArm
+}
+
+// the patterns the subject is checked against, and the block run if one of them matches
+type MatchArm struct {
+	Patterns []AbstractSyntaxTree // literals, enum members, ranges, bindings or wildcards
+	Guard    AbstractSyntaxTree   // the arm is skipped unless it's true, nil without an if
+	Token    types.Token          // the FAT_ARROW token
+	Block    AbstractSyntaxTree   // Program node
+}
+
+// matches any value, ex - _ => { }
+type WildcardPattern struct {
+	Token types.Token
+}
+
+// matches any value and stores it in a new variable for the arm, ex - n if n > 100 => { }
+type BindingPattern struct {
+	Token   types.Token // the name of the variable
+	Binding *Binding    // where the variable is stored, found while scoping
+}
+
+// matches the numbers between two literals, both included. Ex - 1..10
+type RangePattern struct {
+	Low   AbstractSyntaxTree
+	Token types.Token // the RANGE token
+	High  AbstractSyntaxTree
+}
+
+func (wp WildcardPattern) GetToken() types.Token {
+	return wp.Token
+}
+func (wp WildcardPattern) Scope(_ *Interpreter) {}
+
+func (bp BindingPattern) GetToken() types.Token {
+	return bp.Token
+}
+func (bp BindingPattern) Scope(_ *Interpreter) {}
+
+func (rp RangePattern) GetToken() types.Token {
+	return rp.Token
+}
+func (rp RangePattern) Scope(_ *Interpreter) {}
+
+func (ms MatchStatement) GetToken() types.Token {
+	return ms.Token
+}
+
+/*
+	Every arm starts from what was assigned before the match, like the branches of a conditional, and gets a
+	scope of its own for the variable of its binding pattern.
+
+	Warns about the arms after one which matches everything, and about matches over an enum or a bool which
+	leave some of the values without an arm
+*/
+func (ms MatchStatement) Scope(i *Interpreter) {
+	ms.Subject.Scope(i)
+	subjectType := i.TypeOf(ms.Subject)
+
+	before := i.Assignments.snapshot()
+	var branches []map[variableKey]bool
+
+	matchedEverything, matchedNone := false, false
+
+	for _, arm := range ms.Arms {
+		if matchedEverything {
+			i.Warn("This arm is never reached, an arm before it matches every value", arm.Token)
+		}
+
+		i.Assignments.restore(before)
+
+		// the value of a binding after an arm for none is never none
+		bindingType := subjectType
+
+		if matchedNone {
+			bindingType = NonOptionalType(subjectType)
+		}
+
+		i.EnterScope(constants.MATCH)
+
+		for _, pattern := range arm.Patterns {
+			i.scopePattern(pattern, subjectType, bindingType, len(arm.Patterns) > 1)
+		}
+
+		if arm.Guard != nil {
+			arm.Guard.Scope(i)
+			i.checkNotNone(arm.Guard, arm.Token)
+		}
+
+		// the block can declare a variable with the name of the binding
+		i.EnterScope(constants.MATCH)
+		arm.Block.Scope(i)
+
+		i.ReleaseScope()
+		i.ReleaseScope()
+
+		branches = append(branches, i.Assignments.snapshot())
+
+		if arm.Guard != nil {
+			continue
+		}
+
+		for _, pattern := range arm.Patterns {
+			switch pattern.(type) {
+			case WildcardPattern, BindingPattern:
+				matchedEverything = true
+
+			case NoneLiteral:
+				matchedNone = true
+			}
+		}
+	}
+
+	// without an arm for every value, none of the blocks might run
+	if !matchedEverything && !i.checkExhaustive(ms, subjectType) {
+		branches = append(branches, before)
+	}
+
+	i.Assignments.restore(intersectAssignments(branches))
+}
+
+// checks the pattern can match a value of the subject's type, and defines the variable of a binding
+func (i *Interpreter) scopePattern(pattern AbstractSyntaxTree, subjectType, bindingType string, alternatives bool) {
+	switch p := pattern.(type) {
+	case WildcardPattern:
+
+	case BindingPattern:
+		// which of the patterns matched wouldn't be known, so neither would the value of the variable
+		if alternatives {
+			errors.ShowError(
+				constants.SEMANTIC_ERROR,
+				constants.ERROR_UNSUPPORTED,
+				fmt.Sprintf("The binding '%s' cannot be one of several patterns", p.Token.Value),
+				p.Token,
+			)
+		}
+
+		symbol := Symbol{
+			Name: p.Token.Value,
+			Type: bindingType,
+		}
+
+		i.DeclareSlot(&symbol)
+		i.Resolve(symbol, p.Binding)
+		i.CurrentScope.DefineSymbol(symbol)
+
+	case RangePattern:
+		low, _ := literalValue(p.Low)
+		high, _ := literalValue(p.High)
+
+		if !low.IsNumber() || !high.IsNumber() {
+			errors.ShowError(
+				constants.TYPE_ERROR,
+				constants.ERROR_TYPE_MISMATCH,
+				fmt.Sprintf("The bounds of a range have to be numbers, got %v%s%v", low, p.Token.Value, high),
+				p.Token,
+			)
+		}
+
+		if low.Number() > high.Number() {
+			errors.ShowError(
+				constants.SEMANTIC_ERROR,
+				constants.ERROR_UNSUPPORTED,
+				fmt.Sprintf("The range %v%s%v doesn't match any value, the low bound comes first", low, p.Token.Value, high),
+				p.Token,
+			)
+		}
+
+		i.checkPatternType(p.Low, subjectType)
+		i.checkPatternType(p.High, subjectType)
+
+	default:
+		pattern.Scope(i)
+		i.checkPatternType(pattern, subjectType)
+	}
+}
+
+// errors if a value of the subject's type can never be equal to the pattern
+func (i *Interpreter) checkPatternType(pattern AbstractSyntaxTree, subjectType string) {
+	patternType := i.TypeOf(pattern)
+
+	if subjectType == "" {
+		return
+	}
+
+	if patternType == constants.NONE_TYPE && !MightBeNone(subjectType) {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_TYPE_MISMATCH,
+			fmt.Sprintf("A value of type %s is never none", subjectType),
+			pattern.GetToken(),
+		)
+	}
+
+	if patternType != constants.NONE_TYPE && !IsAssignable(NonOptionalType(subjectType), patternType) {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_TYPE_MISMATCH,
+			fmt.Sprintf("Cannot match a value of type %s against a pattern of type %s", subjectType, patternType),
+			pattern.GetToken(),
+		)
+	}
+}
+
+/*
+	Whether every value of an enum or a bool subject has an arm without a guard, warns about the ones which
+	don't. Other types can't be listed, so only a wildcard or a binding covers them
+*/
+func (i *Interpreter) checkExhaustive(ms MatchStatement, subjectType string) bool {
+	var values []runtime.Value
+
+	valueType := NonOptionalType(subjectType)
+
+	switch {
+	case valueType == constants.BOOLEAN_TYPE:
+		values = append(values, runtime.BoolValue(true), runtime.BoolValue(false))
+
+	case i.isEnumType(valueType):
+		symbol, _ := i.CurrentScope.LookupSymbol(valueType, false)
+
+		for ordinal := range symbol.Enum.Members {
+			values = append(values, runtime.EnumValue(symbol.Enum, ordinal))
+		}
+
+	default:
+		return false
+	}
+
+	if IsOptionalType(subjectType) {
+		values = append(values, runtime.None)
+	}
+
+	var missing []string
+
+	for _, value := range values {
+		if !ms.matchedWithoutGuard(value) {
+			missing = append(missing, patternName(value))
+		}
+	}
+
+	if len(missing) > 0 {
+		i.Warn(
+			fmt.Sprintf("The match over a %s isn't exhaustive, there's no arm for %s", subjectType, strings.Join(missing, ", ")),
+			ms.Token,
+		)
+	}
+
+	return len(missing) == 0
+}
+
+// whether an arm without a guard always runs for the value
+func (ms MatchStatement) matchedWithoutGuard(value runtime.Value) bool {
+	for _, arm := range ms.Arms {
+		if arm.Guard == nil && arm.matches(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// how a value is written as a pattern, ex - Color.Red
+func patternName(value runtime.Value) string {
+	if value.Kind == runtime.ENUM {
+		return value.Enum.Name + constants.DOT_SYMBOL + value.String()
+	}
+
+	return value.String()
+}
+
+func (i *Interpreter) EvaluateMatchStatement(ms MatchStatement) runtime.Value {
+	var result runtime.Value
+
+	value := i.Visit(ms.Subject)
+
+	for _, arm := range ms.Arms {
+		if !arm.matches(value) {
+			continue
+		}
+
+		if binding, ok := arm.Patterns[0].(BindingPattern); ok {
+			activationRecord, _ := i.CallStack.Peek()
+			activationRecord.SetItem(binding.Binding.Depth, binding.Binding.Slot, value)
+		}
+
+		if arm.Guard != nil && !i.Visit(arm.Guard).Bool {
+			continue
+		}
+
+		i.Visit(arm.Block)
+		break
+	}
+
+	return result
+}
+
+// whether one of the patterns of the arm matches the value, without the guard
+func (arm MatchArm) matches(value runtime.Value) bool {
+	for _, pattern := range arm.Patterns {
+		if matchesPattern(pattern, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesPattern(pattern AbstractSyntaxTree, value runtime.Value) bool {
+	switch p := pattern.(type) {
+	case WildcardPattern, BindingPattern:
+		return true
+
+	case RangePattern:
+		low, _ := literalValue(p.Low)
+		high, _ := literalValue(p.High)
+
+		if !value.IsNumber() {
+			return false
+		}
+
+		// large ints aren't exact as floats
+		if value.Kind == runtime.INT && low.Kind == runtime.INT && high.Kind == runtime.INT {
+			return low.Int <= value.Int && value.Int <= high.Int
+		}
+
+		return low.Number() <= value.Number() && value.Number() <= high.Number()
+	}
+
+	literal, _ := literalValue(pattern)
+
+	return value.Equal(literal)
+}
@@ -0,0 +1,184 @@
+package interpreter
+
+import (
+	"fmt"
+	"math"
+
+	"programminglang/constants"
+	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
+)
+
+// functions of a single float, ex - sqrt, sin
+var unaryMathFunctions = map[string]func(float64) float64{
+	constants.SQRT: math.Sqrt,
+	constants.LOG:  math.Log,
+	constants.EXP:  math.Exp,
+	constants.SIN:  math.Sin,
+	constants.COS:  math.Cos,
+	constants.TAN:  math.Tan,
+}
+
+// functions of a single float that return an integer
+var roundingMathFunctions = map[string]func(float64) float64{
+	constants.FLOOR: math.Floor,
+	constants.CEIL:  math.Ceil,
+	constants.ROUND: math.Round,
+}
+
+var binaryMathFunctions = map[string]func(float64, float64) float64{
+	constants.POW:   math.Pow,
+	constants.ATAN2: math.Atan2,
+}
+
+func init() {
+	RegisterNativeConstant(constants.PI, runtime.FloatValue(float32(math.Pi)))
+	RegisterNativeConstant(constants.E, runtime.FloatValue(float32(math.E)))
+
+	for name := range unaryMathFunctions {
+		RegisterNativeFunction(NativeFunction{
+			Name:       name,
+			ParamTypes: []string{constants.NUMBER_TYPE},
+			ReturnType: constants.FLOAT_TYPE,
+			Call:       nativeUnaryMath,
+		})
+	}
+
+	for name := range roundingMathFunctions {
+		RegisterNativeFunction(NativeFunction{
+			Name:       name,
+			ParamTypes: []string{constants.NUMBER_TYPE},
+			ReturnType: constants.INTEGER_TYPE,
+			Call:       nativeRoundingMath,
+		})
+	}
+
+	for name := range binaryMathFunctions {
+		RegisterNativeFunction(NativeFunction{
+			Name:       name,
+			ParamTypes: []string{constants.NUMBER_TYPE, constants.NUMBER_TYPE},
+			ReturnType: constants.FLOAT_TYPE,
+			Call:       nativeBinaryMath,
+		})
+	}
+
+	// abs, min and max return an int when all of their arguments are ints
+	RegisterNativeFunction(NativeFunction{
+		Name:       constants.ABS,
+		ParamTypes: []string{constants.NUMBER_TYPE},
+		ReturnType: constants.NUMBER_TYPE,
+		Call:       nativeAbs,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:       constants.MIN,
+		ParamTypes: []string{constants.NUMBER_TYPE, constants.NUMBER_TYPE},
+		ReturnType: constants.NUMBER_TYPE,
+		Call:       nativeMinMax,
+	})
+
+	RegisterNativeFunction(NativeFunction{
+		Name:       constants.MAX,
+		ParamTypes: []string{constants.NUMBER_TYPE, constants.NUMBER_TYPE},
+		ReturnType: constants.NUMBER_TYPE,
+		Call:       nativeMinMax,
+	})
+}
+
+// converts the arguments of a math function to floats, raising an error for anything that isn't a number
+func mathArguments(f FunctionCall, args []runtime.Value) []float64 {
+	var floats []float64
+
+	for _, arg := range args {
+		if !arg.IsNumber() {
+			errors.ShowError(
+				constants.TYPE_ERROR,
+				constants.ERROR_INVALID_ARGUMENT,
+				fmt.Sprintf("%s expects numbers, got '%v'", f.FunctionName, arg),
+				f.Token,
+			)
+		}
+
+		floats = append(floats, float64(arg.Number()))
+	}
+
+	return floats
+}
+
+func mathDomainError(f FunctionCall, value float64) {
+	errors.ShowError(
+		constants.RUNTIME_ERROR,
+		constants.ERROR_INVALID_ARGUMENT,
+		fmt.Sprintf("%s is not defined for %v", f.FunctionName, value),
+		f.Token,
+	)
+}
+
+func nativeUnaryMath(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	value := mathArguments(f, args)[0]
+
+	if (f.FunctionName == constants.SQRT && value < 0) || (f.FunctionName == constants.LOG && value <= 0) {
+		mathDomainError(f, value)
+	}
+
+	return runtime.FloatValue(float32(unaryMathFunctions[f.FunctionName](value)))
+}
+
+func nativeRoundingMath(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	value := roundingMathFunctions[f.FunctionName](mathArguments(f, args)[0])
+
+	// nan, inf and the floats past the range of int have no int to round to, same as int()
+	if math.IsNaN(value) || value < math.MinInt64 || value >= -math.MinInt64 {
+		errors.ShowError(
+			constants.RUNTIME_ERROR,
+			constants.ERROR_INVALID_ARGUMENT,
+			fmt.Sprintf("%s of '%v' is out of the range of int", f.FunctionName, args[0]),
+			f.Token,
+		)
+	}
+
+	return runtime.IntValue(int(value))
+}
+
+func nativeBinaryMath(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	values := mathArguments(f, args)
+
+	return runtime.FloatValue(float32(binaryMathFunctions[f.FunctionName](values[0], values[1])))
+}
+
+func nativeAbs(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	value := mathArguments(f, args)[0]
+
+	if args[0].Kind == runtime.INT {
+		if args[0].Int < 0 {
+			return runtime.IntValue(-args[0].Int)
+		}
+
+		return args[0]
+	}
+
+	return runtime.FloatValue(float32(math.Abs(value)))
+}
+
+func nativeMinMax(i *Interpreter, f FunctionCall, args []runtime.Value) runtime.Value {
+	values := mathArguments(f, args)
+
+	pickFirst := values[0] <= values[1]
+
+	if f.FunctionName == constants.MAX {
+		pickFirst = values[0] >= values[1]
+	}
+
+	picked := args[1]
+
+	if pickFirst {
+		picked = args[0]
+	}
+
+	// mixing ints and floats gives a float
+	if picked.Kind == runtime.INT && args[0].Kind != args[1].Kind {
+		return runtime.FloatValue(picked.Number())
+	}
+
+	return picked
+}
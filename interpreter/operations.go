@@ -60,7 +60,14 @@ func (b BinaryOperationNode) GetToken() types.Token {
 }
 func (b BinaryOperationNode) Scope(s *Interpreter) {
 	b.Left.Scope(s)
+	s.expectConcatenated(b)
 	b.Right.Scope(s)
+
+	s.checkNotNone(b.Left, b.Operation)
+	s.checkNotNone(b.Right, b.Operation)
+	s.checkNotEnum(b.Left, b.Operation)
+	s.checkNotEnum(b.Right, b.Operation)
+	s.checkConcatenation(b)
 }
 
 func (b BinaryOperationNode) GetLeftOperandToken() types.Token {
@@ -76,4 +83,6 @@ func (u UnaryOperationNode) GetToken() types.Token {
 }
 func (u UnaryOperationNode) Scope(s *Interpreter) {
 	u.Operand.Scope(s)
+	s.checkNotNone(u.Operand, u.Operation)
+	s.checkNotEnum(u.Operand, u.Operation)
 }
@@ -0,0 +1,118 @@
+package interpreter
+
+import (
+	"programminglang/constants"
+)
+
+/*
+	Optimizes the scoped tree before it's run, depending on the interpreter's OptLevel.
+
+	With constants.OPT_LEVEL_FULL operations on literals are evaluated once here instead of every time they run,
+	additions of 0 and multiplications by 1 are removed, and so are the branches of conditionals that can never run
+*/
+func (i *Interpreter) Optimize(node AbstractSyntaxTree) AbstractSyntaxTree {
+	if i.OptLevel == constants.OPT_LEVEL_NONE {
+		return i.SubstituteConstants(node)
+	}
+
+	return transformTree(node, func(n AbstractSyntaxTree) AbstractSyntaxTree {
+		return i.simplify(i.foldLiterals(substituteConstant(n)))
+	})
+}
+
+func (i *Interpreter) simplify(node AbstractSyntaxTree) AbstractSyntaxTree {
+	switch n := node.(type) {
+	case BinaryOperationNode:
+		return i.simplifyIdentity(n)
+
+	case ConditionalStatement:
+		return simplifyConditional(n)
+	}
+
+	return node
+}
+
+// x * 1, 1 * x, x + 0, 0 + x and x - 0 become x
+func (i *Interpreter) simplifyIdentity(b BinaryOperationNode) AbstractSyntaxTree {
+	var identity int
+
+	switch b.Operation.Type {
+	case constants.MUL:
+		identity = 1
+	case constants.PLUS, constants.MINUS:
+		identity = 0
+	default:
+		return b
+	}
+
+	if i.isIdentityFor(b.Right, identity, b.Left) {
+		return b.Left
+	}
+
+	// 0 - x is -x, not x
+	if b.Operation.Type != constants.MINUS && i.isIdentityFor(b.Left, identity, b.Right) {
+		return b.Right
+	}
+
+	return b
+}
+
+/*
+	Whether the literal is the identity for an operation on the operand, without changing the result's type.
+	Ex - x * 1.0 is a float even if x is an int, so it can't become just x
+*/
+func (i *Interpreter) isIdentityFor(literal AbstractSyntaxTree, identity int, operand AbstractSyntaxTree) bool {
+	operandType := i.TypeOf(operand)
+
+	switch value := literal.(type) {
+	case IntegerNumber:
+		return value.Value == identity && (operandType == constants.INTEGER_TYPE || operandType == constants.FLOAT_TYPE)
+
+	case FloatNumber:
+		return value.Value == float32(identity) && operandType == constants.FLOAT_TYPE
+	}
+
+	return false
+}
+
+/*
+	Removes the branches whose condition is false, and the ones after a branch whose condition is true.
+	Ex - if false { a } elif x { b } becomes if x { b }, and if true { a } becomes a
+*/
+func simplifyConditional(cs ConditionalStatement) AbstractSyntaxTree {
+	var branches []ConditionalStatement
+
+	for _, branch := range append([]ConditionalStatement{cs}, cs.Ladder...) {
+		branch.Ladder = nil
+
+		if condition, ok := branch.Conditionals.(Boolean); ok {
+			if !condition.Value {
+				continue
+			}
+
+			// always taken, so it's the else of the remaining branches
+			branch.Type = constants.ELSE
+			branch.Conditionals = nil
+		}
+
+		branches = append(branches, branch)
+
+		if branch.Type == constants.ELSE {
+			break
+		}
+	}
+
+	if len(branches) == 0 {
+		return BlankStatement{Token: cs.Token}
+	}
+
+	if branches[0].Type == constants.ELSE {
+		return branches[0].ConditionalBlock
+	}
+
+	head := branches[0]
+	head.Type = constants.IF
+	head.Ladder = branches[1:]
+
+	return head
+}
@@ -0,0 +1,281 @@
+package interpreter
+
+import (
+	"fmt"
+	"strings"
+
+	"programminglang/constants"
+	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
+	"programminglang/types"
+)
+
+// the explicit absence of a value, ex - let port: int? = none;
+type NoneLiteral struct {
+	Token types.Token
+}
+
+// the value of an optional, or the default if it's none. Ex - port ?? 8080
+type CoalesceNode struct {
+	Left  AbstractSyntaxTree
+	Token types.Token // the COALESCE token
+	Right AbstractSyntaxTree
+}
+
+func (nl NoneLiteral) GetToken() types.Token {
+	return nl.Token
+}
+func (nl NoneLiteral) Scope(_ *Interpreter) {}
+
+func (cn CoalesceNode) GetToken() types.Token {
+	return cn.Token
+}
+
+// the left side has to be optional, and the default has to fit in it
+func (cn CoalesceNode) Scope(i *Interpreter) {
+	cn.Left.Scope(i)
+	cn.Right.Scope(i)
+
+	leftType, rightType := i.TypeOf(cn.Left), i.TypeOf(cn.Right)
+
+	if leftType == "" {
+		return
+	}
+
+	if !MightBeNone(leftType) {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_TYPE_MISMATCH,
+			fmt.Sprintf("The left side of '%s' has to be optional, a value of type %s is never none", cn.Token.Value, leftType),
+			cn.Token,
+		)
+	}
+
+	if leftType != constants.NONE_TYPE && !IsAssignable(leftType, rightType) {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_TYPE_MISMATCH,
+			fmt.Sprintf("Cannot use a value of type %s as the default of a %s", rightType, leftType),
+			cn.Token,
+		)
+	}
+}
+
+// the right side is only evaluated if the left one is none
+func (i *Interpreter) EvaluateCoalesceNode(cn CoalesceNode) runtime.Value {
+	if left := i.Visit(cn.Left); !left.IsNone() {
+		return left
+	}
+
+	return i.Visit(cn.Right)
+}
+
+// the type of left ?? right, which can only be none if the default can be
+func coalesceType(leftType, rightType string) string {
+	if leftType == "" || rightType == "" {
+		return ""
+	}
+
+	if leftType == constants.NONE_TYPE || MightBeNone(rightType) {
+		return rightType
+	}
+
+	return NonOptionalType(leftType)
+}
+
+/*
+	Errors if the value of node might be none. Optionals have to be compared with none or given a default
+	with ?? before they're used for anything but storing, passing and comparing them
+*/
+func (i *Interpreter) checkNotNone(node AbstractSyntaxTree, token types.Token) {
+	if valueType := i.TypeOf(node); MightBeNone(valueType) {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_TYPE_MISMATCH,
+			fmt.Sprintf(
+				"A value of type %s might be none, check it with != none or give it a default with %s first",
+				valueType, constants.COALESCE_SYMBOL,
+			),
+			token,
+		)
+	}
+}
+
+/*
+	The optional variables which a condition proves aren't none, when it's true or when it's false.
+	Ex - x != none and y != none proves both aren't none when it's true, x == none proves x isn't when it's false.
+
+	The symbols have the type without the ?, so the variables can be used as such where the proof holds
+*/
+func (i *Interpreter) narrowedBy(condition AbstractSyntaxTree, whenTrue bool) []Symbol {
+	switch c := condition.(type) {
+	case ComparisonNode:
+		if c.Comparator.Type != constants.EQUALITY && c.Comparator.Type != constants.NOT_EQUAL_TO {
+			return nil
+		}
+
+		// x == none is only proof when it's false, x != none only when it's true
+		if (c.Comparator.Type == constants.NOT_EQUAL_TO) != whenTrue {
+			return nil
+		}
+
+		variable, isVariable := c.Left.(Variable)
+		other := c.Right
+
+		if !isVariable {
+			variable, isVariable = c.Right.(Variable)
+			other = c.Left
+		}
+
+		if _, isNone := other.(NoneLiteral); !isVariable || !isNone {
+			return nil
+		}
+
+		symbol, exists := i.CurrentScope.LookupSymbol(variable.Value, false)
+
+		if !exists || !IsOptionalType(symbol.Type) {
+			return nil
+		}
+
+		symbol.Type = NonOptionalType(symbol.Type)
+
+		return []Symbol{symbol}
+
+	// an and is true when both sides are, an or is false when both sides are
+	case LogicalNode:
+		if (c.LogicalOperator.Type == constants.AND) == whenTrue {
+			return append(i.narrowedBy(c.Left, whenTrue), i.narrowedBy(c.Right, whenTrue)...)
+		}
+	}
+
+	return nil
+}
+
+/*
+	Defines the narrowed symbols in the current scope, over the optional ones they stand for. They're stored
+	in the same slots, so only the type changes.
+
+	A block assigning to the variable could make it none again, and a function created in it could be called
+	after that, so those blocks keep the optional type. So do the variables with the name of one a function
+	assigns to without declaring it, as the block could call that function
+*/
+func (i *Interpreter) narrow(symbols []Symbol, block AbstractSyntaxTree) {
+	if createsFunctions(block) {
+		return
+	}
+
+	for _, symbol := range symbols {
+		if !assignsTo(block, symbol.Name) && !i.Assignments.inFunctions[symbol.Name] {
+			i.CurrentScope.DefineSymbol(symbol)
+		}
+	}
+}
+
+// whether the variable is assigned to anywhere in block
+func assignsTo(block AbstractSyntaxTree, name string) bool {
+	found := false
+
+	WalkTree(block, func(node AbstractSyntaxTree) bool {
+		for _, target := range assignmentTargets(node) {
+			found = found || target.GetToken().Value == name
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// the names of the variables a function of the program assigns to without declaring them itself
+func assignedInFunctions(program AbstractSyntaxTree) map[string]bool {
+	names := map[string]bool{}
+
+	WalkTree(program, func(node AbstractSyntaxTree) bool {
+		var (
+			params []FunctionParameters
+			block  AbstractSyntaxTree
+		)
+
+		switch n := node.(type) {
+		case FunctionDeclaration:
+			params, block = n.FormalParameters, n.FunctionBlock
+
+		case FunctionLiteral:
+			params, block = n.FormalParameters, n.FunctionBlock
+
+		default:
+			return true
+		}
+
+		locals := map[string]bool{}
+
+		for _, param := range params {
+			locals[param.VariableNode.GetToken().Value] = true
+		}
+
+		if body, ok := block.(Program); ok {
+			for _, declaration := range body.Declarations {
+				if vd, ok := declaration.(VariableDeclaration); ok {
+					locals[vd.VariableNode.GetToken().Value] = true
+				}
+			}
+		}
+
+		// the functions nested in this one are visited on their own
+		WalkTree(block, func(inner AbstractSyntaxTree) bool {
+			switch inner.(type) {
+			case FunctionDeclaration, FunctionLiteral:
+				return false
+			}
+
+			for _, target := range assignmentTargets(inner) {
+				if name := target.GetToken().Value; !locals[name] {
+					names[name] = true
+				}
+			}
+
+			return true
+		})
+
+		return true
+	})
+
+	return names
+}
+
+// the variables a statement assigns to, none for other nodes
+func assignmentTargets(node AbstractSyntaxTree) []AbstractSyntaxTree {
+	switch n := node.(type) {
+	case AssignmentStatement:
+		return []AbstractSyntaxTree{n.Left}
+
+	case DestructuringAssignment:
+		return n.Targets
+	}
+
+	return nil
+}
+
+// returns the name of the optional of a type. Ex - int -> int?
+func OptionalTypeOf(typeName string) string {
+	return typeName + constants.QUESTION_SYMBOL
+}
+
+// the ? at the end of a function type belongs to its return type, ex - fn(int) -> int? is never none itself
+func IsOptionalType(typeName string) bool {
+	return strings.HasSuffix(typeName, constants.QUESTION_SYMBOL) && !IsFunctionType(typeName)
+}
+
+// the type of the value an optional holds when it isn't none. Ex - [str]? -> [str]
+func NonOptionalType(typeName string) string {
+	if !IsOptionalType(typeName) {
+		return typeName
+	}
+
+	return strings.TrimSuffix(typeName, constants.QUESTION_SYMBOL)
+}
+
+// whether a value of the type can be none, which is the case for optionals and none itself
+func MightBeNone(typeName string) bool {
+	return typeName == constants.NONE_TYPE || IsOptionalType(typeName)
+}
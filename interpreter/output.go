@@ -0,0 +1,64 @@
+package interpreter
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"programminglang/constants"
+	"programminglang/helpers"
+	"programminglang/types"
+)
+
+func (i *Interpreter) GetStdout() io.Writer {
+	if i.Stdout == nil {
+		return os.Stdout
+	}
+
+	return i.Stdout
+}
+
+func (i *Interpreter) GetStderr() io.Writer {
+	if i.Stderr == nil {
+		return os.Stderr
+	}
+
+	return i.Stderr
+}
+
+/*
+	Whether to add colors to whatever is written to the writer.
+
+	In auto mode colors are only used for terminals, and never if NO_COLOR is set
+*/
+func (i *Interpreter) UseColor(writer io.Writer) bool {
+	switch i.ColorMode {
+	case constants.COLOR_ALWAYS:
+		return true
+
+	case constants.COLOR_NEVER:
+		return false
+	}
+
+	if noColor, ok := i.LookupEnv(constants.NO_COLOR_ENV); ok && noColor != "" {
+		return false
+	}
+
+	return helpers.IsTerminal(writer)
+}
+
+// writes to the interpreter's stdout in the given color, if colors are enabled
+func (i *Interpreter) ColorPrint(color string, toPrint ...interface{}) {
+	stdout := i.GetStdout()
+
+	helpers.ColorFprint(stdout, i.UseColor(stdout), color, toPrint...)
+}
+
+// reports something suspicious found while scoping on stderr, without stopping the program
+func (i *Interpreter) Warn(message string, token types.Token) {
+	warning := fmt.Sprintf("Warning: %s. %s", message, token.PrintLineCol())
+	i.Warnings = append(i.Warnings, warning)
+
+	stderr := i.GetStderr()
+	helpers.ColorFprint(stderr, i.UseColor(stderr), constants.Yellow, warning, "\n")
+}
@@ -6,6 +6,7 @@ import (
 	"programminglang/constants"
 	"programminglang/helpers"
 	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
 	"programminglang/types"
 )
 
@@ -111,7 +112,7 @@ func (p *Parser) Term() AbstractSyntaxTree {
 }
 
 /*
-	FACTOR --> ((PLUS | MINUS) FACTOR) | INTEGER | LPAREN EXPRESSION RPAREN
+	FACTOR --> ((PLUS | MINUS) FACTOR) | INTEGER | NONE | LPAREN COALESCE RPAREN | VAR_TYPE LPAREN LOGICAL_STATEMENT RPAREN
 */
 func (p *Parser) Factor() AbstractSyntaxTree {
 	token := p.CurrentToken
@@ -168,17 +169,63 @@ func (p *Parser) Factor() AbstractSyntaxTree {
 			Value: false,
 		}
 
+	case constants.NONE:
+		p.ValidateToken(constants.NONE)
+		returningValue = NoneLiteral{
+			Token: token,
+		}
+
 	case constants.LPAREN:
 		p.ValidateToken(constants.LPAREN)
-		returningValue = p.Expression()
+		returningValue = p.LogicalStatement()
+		p.ValidateToken(constants.RPAREN)
+
+	case constants.IF:
+		returningValue = p.IfExpression()
+
+	case constants.FN:
+		returningValue = p.FunctionLiteral()
+
+	case constants.LSQUARE:
+		returningValue = p.ListLiteral()
+
+	case constants.INTEGER_TYPE, constants.FLOAT_TYPE, constants.STRING_TYPE, constants.BOOLEAN_TYPE:
+		// type keyword in call position is a conversion, ex - int("42")
+		p.ValidateToken(token.Type)
+		p.ValidateToken(constants.LPAREN)
+
+		returningValue = TypeConversion{
+			Token:    token,
+			Argument: p.LogicalStatement(),
+		}
+
 		p.ValidateToken(constants.RPAREN)
 
 	default:
-		if p.Lexer.PeekNextToken(1).Type == constants.LPAREN {
+		switch p.Lexer.PeekNextToken(1).Type {
+		case constants.LPAREN:
 			returningValue = p.FunctionCallStatement()
-		} else {
+
+		case constants.DOT:
+			returningValue = p.EnumMember()
+
+		default:
 			returningValue = p.Variable()
 		}
+
+		// list[index] or function()[index]
+		for p.CurrentToken.Type == constants.LSQUARE {
+			token := p.CurrentToken
+			p.ValidateToken(constants.LSQUARE)
+
+			returningValue = IndexNode{
+				Token: token,
+				Left:  returningValue,
+				Index: p.Expression(),
+			}
+
+			p.ValidateToken(constants.RSQUARE)
+		}
 	}
 
 	return returningValue
@@ -217,7 +264,11 @@ func (p *Parser) Expression() AbstractSyntaxTree {
 	return result
 }
 
-// logical_statement --> NOT* (comparator ((AND | OR) comparator)*)
+/*
+	logical_statement --> NOT* (comparator ((AND | OR) comparator)*) (QUESTION logical_statement COLON logical_statement)?
+
+	The ? of a conditional expression comes after a value, the one of an optional type only after a var_type
+*/
 func (p *Parser) LogicalStatement() AbstractSyntaxTree {
 	result := p.ComparisonStatement()
 
@@ -248,12 +299,49 @@ func (p *Parser) LogicalStatement() AbstractSyntaxTree {
 		}
 	}
 
+	// a conditional expression, ex - x > 0 ? x : -x
+	if p.CurrentToken.Type == constants.QUESTION {
+		token := p.CurrentToken
+		p.ValidateToken(constants.QUESTION)
+
+		whenTrue := p.LogicalStatement()
+		p.ValidateToken(constants.COLON)
+
+		result = ConditionalExpression{
+			Condition: result,
+			Token:     token,
+			WhenTrue:  whenTrue,
+			WhenFalse: p.LogicalStatement(),
+			Binding:   &Binding{},
+		}
+	}
+
 	return result
 }
 
-// comparison --> expression comparator expression
+// if_expression --> IF logical_statement THEN logical_statement ELSE logical_statement
+func (p *Parser) IfExpression() AbstractSyntaxTree {
+	token := p.CurrentToken
+	p.ValidateToken(constants.IF)
+
+	condition := p.LogicalStatement()
+	p.ValidateToken(constants.THEN)
+
+	whenTrue := p.LogicalStatement()
+	p.ValidateToken(constants.ELSE)
+
+	return ConditionalExpression{
+		Condition: condition,
+		Token:     token,
+		WhenTrue:  whenTrue,
+		WhenFalse: p.LogicalStatement(),
+		Binding:   &Binding{},
+	}
+}
+
+// comparison --> coalesce comparator coalesce
 func (p *Parser) ComparisonStatement() AbstractSyntaxTree {
-	result := p.Expression()
+	result := p.Coalesce()
 
 	for helpers.ValueInSlice(p.CurrentToken.Type, constants.COMPARATORS_SLICE) {
 		currentToken := p.CurrentToken
@@ -286,7 +374,25 @@ func (p *Parser) ComparisonStatement() AbstractSyntaxTree {
 		result = ComparisonNode{
 			Left:       result,
 			Comparator: currentToken,
-			Right:      p.Expression(),
+			Right:      p.Coalesce(),
+		}
+	}
+
+	return result
+}
+
+// the value of an optional or a default if it's none, coalesce --> expression (COALESCE expression)*
+func (p *Parser) Coalesce() AbstractSyntaxTree {
+	result := p.Expression()
+
+	for p.CurrentToken.Type == constants.COALESCE {
+		currentToken := p.CurrentToken
+		p.ValidateToken(constants.COALESCE)
+
+		result = CoalesceNode{
+			Left:  result,
+			Token: currentToken,
+			Right: p.Expression(),
 		}
 	}
 
@@ -305,29 +411,107 @@ func (p *Parser) Program() AbstractSyntaxTree {
 	return node
 }
 
-// declarations --> LET (variable_declaration SEMI)+ (function)* | blank
+// declarations --> (((LET | CONST) variable_declaration SEMI) | function | enum)* | blank
 func (p *Parser) Declarations() []AbstractSyntaxTree {
 	var declarations []AbstractSyntaxTree
 
 	// variables are defined as, let varialble_name(s) : variable_type;
-	for p.CurrentToken.Type == constants.LET {
-		p.ValidateToken(constants.LET)
+	// and constants as, const constant_name : constant_type = value;
+	// functions and enums can come in between, so variables can be initialized with them
+	for helpers.ValueInSlice(p.CurrentToken.Type, constants.DECLARATION_KEYWORDS) {
+		// for functions
+		if p.CurrentToken.Type == constants.DEFINE {
+			declarations = append(declarations, p.FunctionDeclaration())
+			continue
+		}
+
+		if p.CurrentToken.Type == constants.ENUM {
+			declarations = append(declarations, p.EnumDeclaration())
+			continue
+		}
+
+		isConstant := p.CurrentToken.Type == constants.CONST
+		p.ValidateToken(p.CurrentToken.Type)
+
+		for _, varDeclaration := range p.VariableDeclaration() {
+			if isConstant {
+				declaration := varDeclaration.(VariableDeclaration)
+				declaration.Constant = true
+				varDeclaration = declaration
+			}
+
+			declarations = append(declarations, varDeclaration)
+		}
 
-		varDeclaration := p.VariableDeclaration()
-		declarations = append(declarations, varDeclaration...)
 		p.ValidateToken(constants.SEMI_COLON)
 	}
 
-	// for functions
-	for p.CurrentToken.Type == constants.DEFINE {
-		functionDeclaration := p.FunctionDeclaration()
-		declarations = append(declarations, functionDeclaration)
+	return declarations
+}
+
+// enum --> ENUM ID LCURLY ID (COMMA ID)* RCURLY
+func (p *Parser) EnumDeclaration() AbstractSyntaxTree {
+	p.ValidateToken(constants.ENUM)
+
+	token := p.CurrentToken
+	p.ValidateToken(constants.IDENTIFIER)
+	p.ValidateToken(constants.LCURLY)
+
+	members := []types.Token{p.CurrentToken}
+	p.ValidateToken(constants.IDENTIFIER)
+
+	for p.CurrentToken.Type == constants.COMMA {
+		p.ValidateToken(constants.COMMA)
+
+		members = append(members, p.CurrentToken)
+		p.ValidateToken(constants.IDENTIFIER)
+	}
+
+	p.ValidateToken(constants.RCURLY)
+
+	enum := &runtime.Enum{Name: token.Value}
+
+	for _, member := range members {
+		enum.Members = append(enum.Members, member.Value)
 	}
 
-	return declarations
+	return EnumDeclaration{
+		Token:   token,
+		Members: members,
+		Enum:    enum,
+	}
+}
+
+// enum_member --> ID DOT (ID | FROM_ORDINAL LPAREN expression RPAREN)
+func (p *Parser) EnumMember() AbstractSyntaxTree {
+	token := p.CurrentToken
+	p.ValidateToken(constants.IDENTIFIER)
+	p.ValidateToken(constants.DOT)
+
+	member := p.CurrentToken
+	p.ValidateToken(constants.IDENTIFIER)
+
+	// the member with an ordinal, ex - Color.from_ordinal(2)
+	if member.Value == constants.FROM_ORDINAL && p.CurrentToken.Type == constants.LPAREN {
+		p.ValidateToken(constants.LPAREN)
+		argument := p.Expression()
+		p.ValidateToken(constants.RPAREN)
+
+		return EnumFromOrdinal{
+			Token:    token,
+			Argument: argument,
+			Binding:  &Binding{},
+		}
+	}
+
+	return EnumMember{
+		Token:   token,
+		Member:  member,
+		Binding: &Binding{},
+	}
 }
 
-// variable_declaration --> ID (COMMA ID)* COLON var_type
+// variable_declaration --> ID (COMMA ID)* ((COLON var_type (EQUAL logical_statement)?) | (EQUAL logical_statement))
 func (p *Parser) VariableDeclaration() []AbstractSyntaxTree {
 	// make a new slice to store all the variable declarations
 	var variableDeclarations []AbstractSyntaxTree
@@ -342,9 +526,18 @@ func (p *Parser) VariableDeclaration() []AbstractSyntaxTree {
 		p.ValidateToken(constants.IDENTIFIER)
 	}
 
-	p.ValidateToken(constants.COLON)
+	var variableType, initializer AbstractSyntaxTree
 
-	variableType := p.VarType()
+	// the type can be left out if there's an initializer, ex - let x = 5;
+	if p.CurrentToken.Type != constants.EQUAL {
+		p.ValidateToken(constants.COLON)
+		variableType = p.VarType()
+	}
+
+	if p.CurrentToken.Type == constants.EQUAL {
+		p.ValidateToken(constants.EQUAL)
+		initializer = p.LogicalStatement()
+	}
 
 	for _, varToken := range variableTokens {
 
@@ -352,6 +545,7 @@ func (p *Parser) VariableDeclaration() []AbstractSyntaxTree {
 			Token:   varToken,
 			Value:   varToken.Value,
 			VarType: &variableType,
+			Binding: &Binding{},
 		}
 
 		// helpers.ColorPrint(constants.Blue, 1, 1, "variable node = ", constants.SpewPrinter.Sdump(varNode))
@@ -359,13 +553,14 @@ func (p *Parser) VariableDeclaration() []AbstractSyntaxTree {
 		variableDeclarations = append(variableDeclarations, VariableDeclaration{
 			VariableNode: varNode,
 			TypeNode:     variableType,
+			Initializer:  initializer,
 		})
 	}
 
 	return variableDeclarations
 }
 
-// function_call --> ID LPAREN (expression (COMMA expression)*)? RPAREN
+// function_call --> ID LPAREN (argument (COMMA argument)* ELLIPSIS?)? RPAREN
 func (p *Parser) FunctionCallStatement() AbstractSyntaxTree {
 
 	token := p.CurrentToken
@@ -376,18 +571,33 @@ func (p *Parser) FunctionCallStatement() AbstractSyntaxTree {
 	p.ValidateToken(constants.LPAREN)
 
 	var actualParameters []AbstractSyntaxTree
+	var argumentNames []string
+	named := false
+
+	addArgument := func() {
+		node, name := p.Argument()
+
+		actualParameters = append(actualParameters, node)
+		argumentNames = append(argumentNames, name)
+		named = named || name != ""
+	}
 
 	if p.CurrentToken.Type != constants.RPAREN {
 		// has actual arguments and isn't just function()
-		node := p.LogicalStatement()
-		actualParameters = append(actualParameters, node)
+		addArgument()
 	}
 
 	// could be any number of parameters delimited by a comma
 	for p.CurrentToken.Type == constants.COMMA {
 		p.ValidateToken(constants.COMMA)
-		node := p.LogicalStatement()
-		actualParameters = append(actualParameters, node)
+		addArgument()
+	}
+
+	// the last argument is a list spread into the variadic parameter, ex - sum(xs...)
+	spread := len(actualParameters) > 0 && p.CurrentToken.Type == constants.ELLIPSIS
+
+	if spread {
+		p.ValidateToken(constants.ELLIPSIS)
 	}
 
 	// all arguments are parsed, now check for a right parenthesis
@@ -397,21 +607,41 @@ func (p *Parser) FunctionCallStatement() AbstractSyntaxTree {
 		FunctionName:     funcName,
 		ActualParameters: actualParameters,
 		Token:            token,
+		Spread:           spread,
+		Binding:          &Binding{},
+	}
+
+	if named {
+		functionCallNode.ArgumentNames = argumentNames
 	}
 
 	return functionCallNode
 }
 
-// function --> DEFINE ID LPAREN formal_parameters_list? RPAREN LCURLY block RCURLY
+// argument --> (ID EQUAL)? logical_statement, returns the argument and its name, empty if it isn't named
+func (p *Parser) Argument() (AbstractSyntaxTree, string) {
+	node := p.LogicalStatement()
+
+	// connect("db", port = 5432), the name is parsed as a variable first
+	if v, ok := node.(Variable); ok && p.CurrentToken.Type == constants.EQUAL {
+		p.ValidateToken(constants.EQUAL)
+
+		return p.LogicalStatement(), v.Value
+	}
+
+	return node, ""
+}
+
+// function --> DEFINE ID LPAREN formal_parameters_list? RPAREN return_type? LCURLY block (RETURN expression (COMMA expression)*)? RCURLY
 func (p *Parser) FunctionDeclaration() AbstractSyntaxTree {
 	p.ValidateToken(constants.DEFINE)
 
+	token := p.CurrentToken
 	functionName := p.CurrentToken.Value
 
 	p.ValidateToken(constants.IDENTIFIER)
 
 	var parametersList []FunctionParameters
-	var returnStatement AbstractSyntaxTree
 
 	if p.CurrentToken.Type == constants.LPAREN {
 		p.ValidateToken(constants.LPAREN)
@@ -419,12 +649,123 @@ func (p *Parser) FunctionDeclaration() AbstractSyntaxTree {
 		p.ValidateToken(constants.RPAREN)
 	}
 
+	returnType := p.ReturnType()
+	functionBlock, returnStatement := p.FunctionBody()
+
+	function := FunctionDeclaration{
+		Token:            token,
+		FunctionName:     functionName,
+		FunctionBlock:    functionBlock,
+		FormalParameters: parametersList,
+		ReturningValue:   returnStatement,
+		ReturnTypeNode:   returnType,
+		Symbol:           &Symbol{},
+	}
+
+	// helpers.ColorPrint(constants.LightGreen, 1, 1, constants.SpewPrinter.Sdump(function))
+
+	return function
+}
+
+// function_literal --> FN LPAREN formal_parameters_list? RPAREN return_type? LCURLY block (RETURN expression (COMMA expression)*)? RCURLY
+func (p *Parser) FunctionLiteral() AbstractSyntaxTree {
+	token := p.CurrentToken
+
+	p.ValidateToken(constants.FN)
+	p.ValidateToken(constants.LPAREN)
+	parametersList := p.FormalParametersList()
+
+	// a function value is called through its type, which has no defaults, ex - fn(int, int) -> int
+	for _, param := range parametersList {
+		if param.Default != nil {
+			errors.ShowError(
+				constants.PARSER_ERROR,
+				constants.ERROR_UNEXPECTED_TOKEN,
+				fmt.Sprintf("Parameter '%s' of a fn literal can't have a default value", param.VariableNode.GetToken().Value),
+				param.VariableNode.GetToken(),
+			)
+		}
+	}
+
+	p.ValidateToken(constants.RPAREN)
+
+	returnType := p.ReturnType()
+	functionBlock, returnStatement := p.FunctionBody()
+
+	return FunctionLiteral{
+		Token:            token,
+		FunctionBlock:    functionBlock,
+		FormalParameters: parametersList,
+		ReturningValue:   returnStatement,
+		ReturnTypeNode:   returnType,
+		Symbol:           &Symbol{},
+	}
+}
+
+// the optional return type of a function, return_type --> ARROW (var_type | LPAREN var_type (COMMA var_type)* RPAREN)
+func (p *Parser) ReturnType() AbstractSyntaxTree {
+	if p.CurrentToken.Type != constants.ARROW {
+		return nil
+	}
+
+	p.ValidateToken(constants.ARROW)
+
+	if p.CurrentToken.Type != constants.LPAREN {
+		return p.VarType()
+	}
+
+	// a function returning several values, ex - -> (int, str)
+	token := p.CurrentToken
+	p.ValidateToken(constants.LPAREN)
+
+	elementTypes := []string{p.VarType().GetToken().Value}
+
+	for p.CurrentToken.Type == constants.COMMA {
+		p.ValidateToken(constants.COMMA)
+		elementTypes = append(elementTypes, p.VarType().GetToken().Value)
+	}
+
+	p.ValidateToken(constants.RPAREN)
+
+	// -> (int) is just an int
+	token.Value = elementTypes[0]
+
+	if len(elementTypes) > 1 {
+		token.Value = TupleTypeOf(elementTypes)
+	}
+
+	return VariableType{
+		Token: token,
+	}
+}
+
+// the block of a function and the value it returns, LCURLY block (RETURN expression (COMMA expression)*)? RCURLY
+func (p *Parser) FunctionBody() (AbstractSyntaxTree, AbstractSyntaxTree) {
+	var returnStatement AbstractSyntaxTree
+
 	p.ValidateToken(constants.LCURLY)
 	functionBlock := p.Program()
 
 	if p.CurrentToken.Type == constants.RETURN {
+		token := p.CurrentToken
+
 		p.ValidateToken(constants.RETURN)
 		returnStatement = p.LogicalStatement()
+
+		// several values are returned as a tuple, ex - return q, r;
+		if p.CurrentToken.Type == constants.COMMA {
+			elements := []AbstractSyntaxTree{returnStatement}
+
+			for p.CurrentToken.Type == constants.COMMA {
+				p.ValidateToken(constants.COMMA)
+				elements = append(elements, p.LogicalStatement())
+			}
+
+			returnStatement = TupleLiteral{
+				Token:    token,
+				Elements: elements,
+			}
+		}
 	}
 
 	if p.CurrentToken.Type == constants.SEMI_COLON {
@@ -433,16 +774,33 @@ func (p *Parser) FunctionDeclaration() AbstractSyntaxTree {
 
 	p.ValidateToken(constants.RCURLY)
 
-	function := FunctionDeclaration{
-		FunctionName:     functionName,
-		FunctionBlock:    functionBlock,
-		FormalParameters: parametersList,
-		ReturningValue:   returnStatement,
+	return functionBlock, returnStatement
+}
+
+// list_literal --> LSQUARE (logical_statement (COMMA logical_statement)*)? RSQUARE
+func (p *Parser) ListLiteral() AbstractSyntaxTree {
+	token := p.CurrentToken
+
+	p.ValidateToken(constants.LSQUARE)
+
+	var elements []AbstractSyntaxTree
+
+	if p.CurrentToken.Type != constants.RSQUARE {
+		elements = append(elements, p.LogicalStatement())
 	}
 
-	// helpers.ColorPrint(constants.LightGreen, 1, 1, constants.SpewPrinter.Sdump(function))
+	for p.CurrentToken.Type == constants.COMMA {
+		p.ValidateToken(constants.COMMA)
+		elements = append(elements, p.LogicalStatement())
+	}
 
-	return function
+	p.ValidateToken(constants.RSQUARE)
+
+	return ListLiteral{
+		Token:    token,
+		Elements: elements,
+		Binding:  &Binding{},
+	}
 }
 
 // formal_parameter_list --> formal_parameters | formal_parameters SEMI_COLON formal_parameter_list
@@ -463,7 +821,7 @@ func (p *Parser) FormalParametersList() []FunctionParameters {
 	return paramNodes
 }
 
-// formal_parameters --> ID (COMMA ID)* COLON type_spec
+// formal_parameters --> ID (COMMA ID)* COLON (ELLIPSIS type_spec | type_spec (EQUAL logical_statement)?)
 func (p *Parser) FormalParameters() []FunctionParameters {
 	var paramNodes []FunctionParameters
 
@@ -479,8 +837,29 @@ func (p *Parser) FormalParameters() []FunctionParameters {
 
 	p.ValidateToken(constants.COLON)
 
+	// a variadic parameter, the arguments arrive as a list. Ex - nums: ...int is an [int] in the function
+	variadic := p.CurrentToken.Type == constants.ELLIPSIS
+
+	if variadic {
+		p.ValidateToken(constants.ELLIPSIS)
+	}
+
 	typeNode := p.VarType()
 
+	if variadic {
+		token := typeNode.GetToken()
+		token.Value = ListTypeOf(token.Value)
+		typeNode = VariableType{Token: token}
+	}
+
+	// a default value, ex - port: int = 8080
+	var defaultValue AbstractSyntaxTree
+
+	if !variadic && p.CurrentToken.Type == constants.EQUAL {
+		p.ValidateToken(constants.EQUAL)
+		defaultValue = p.LogicalStatement()
+	}
+
 	for _, parameterToken := range paramTokens {
 		paramNodes = append(paramNodes, FunctionParameters{
 			VariableNode: Variable{
@@ -489,6 +868,8 @@ func (p *Parser) FormalParameters() []FunctionParameters {
 				VarType: &typeNode,
 			},
 			TypeNode: typeNode,
+			Default:  defaultValue,
+			Variadic: variadic,
 		})
 	}
 
@@ -496,11 +877,57 @@ func (p *Parser) FormalParameters() []FunctionParameters {
 
 }
 
-// var_type --> INTEGER_TYPE | FLOAT_TYPE | STRING_TYPE | BOOLEAN_TYPE
+/*
+	var_type --> (INTEGER_TYPE | FLOAT_TYPE | STRING_TYPE | BOOLEAN_TYPE | ID | LSQUARE var_type RSQUARE) QUESTION?
+				 | FN LPAREN (ELLIPSIS? var_type (COMMA ELLIPSIS? var_type)*)? RPAREN (ARROW var_type)?
+*/
 func (p *Parser) VarType() AbstractSyntaxTree {
 	token := p.CurrentToken
 
 	switch token.Type {
+	case constants.FN:
+		// function type, ex - fn(int, int) -> int
+		p.ValidateToken(constants.FN)
+		p.ValidateToken(constants.LPAREN)
+
+		var paramTypes []string
+
+		// the last parameter can be variadic, ex - fn(str, ...int)
+		paramType := func() string {
+			if p.CurrentToken.Type == constants.ELLIPSIS {
+				p.ValidateToken(constants.ELLIPSIS)
+				return constants.ELLIPSIS_SYMBOL + p.VarType().GetToken().Value
+			}
+
+			return p.VarType().GetToken().Value
+		}
+
+		if p.CurrentToken.Type != constants.RPAREN {
+			paramTypes = append(paramTypes, paramType())
+		}
+
+		for p.CurrentToken.Type == constants.COMMA {
+			p.ValidateToken(constants.COMMA)
+			paramTypes = append(paramTypes, paramType())
+		}
+
+		p.ValidateToken(constants.RPAREN)
+
+		var returnType string
+
+		if returnTypeNode := p.ReturnType(); returnTypeNode != nil {
+			returnType = returnTypeNode.GetToken().Value
+		}
+
+		token.Value = FunctionTypeOf(paramTypes, returnType)
+
+	case constants.LSQUARE:
+		// list type, the element type is stored inside the brackets, ex - [str]
+		p.ValidateToken(constants.LSQUARE)
+		elementType := p.VarType()
+		p.ValidateToken(constants.RSQUARE)
+
+		token.Value = ListTypeOf(elementType.GetToken().Value)
 	case constants.INTEGER_TYPE:
 		p.ValidateToken(constants.INTEGER_TYPE)
 	case constants.FLOAT_TYPE:
@@ -509,6 +936,15 @@ func (p *Parser) VarType() AbstractSyntaxTree {
 		p.ValidateToken(constants.STRING_TYPE)
 	case constants.BOOLEAN_TYPE:
 		p.ValidateToken(constants.BOOLEAN_TYPE)
+	case constants.IDENTIFIER:
+		// the name of an enum, ex - Color
+		p.ValidateToken(constants.IDENTIFIER)
+	}
+
+	// an optional type, which can also hold none, ex - int?. For function types it's part of the return type
+	if p.CurrentToken.Type == constants.QUESTION {
+		p.ValidateToken(constants.QUESTION)
+		token.Value = OptionalTypeOf(token.Value)
 	}
 
 	// if p.CurrentToken.Type == constants.SEMI_COLON {
@@ -569,6 +1005,9 @@ func (p *Parser) Statement() AbstractSyntaxTree {
 			// helpers.ColorPrint(constants.Yellow, 0, 1, "calling assignment_statement")
 			// variable definition
 			node = p.AssignmentStatement()
+		} else if p.Lexer.PeekNextToken(1).Type == constants.COMMA {
+			// q, r := divmod(7, 2)
+			node = p.DestructuringAssignment()
 		} else {
 			// helpers.ColorPrint(constants.Yellow, 1, 1, "calling logical_statement")
 			node = p.LogicalStatement()
@@ -586,6 +1025,9 @@ func (p *Parser) Statement() AbstractSyntaxTree {
 			p.CurrentToken,
 		)
 
+	} else if p.CurrentToken.Type == constants.MATCH {
+		node = p.MatchStatement()
+
 	} else if p.CurrentToken.Type == constants.LOOP {
 		// helpers.ColorPrint(constants.Yellow, 1, 1, "calling ParseLoop")
 
@@ -645,6 +1087,125 @@ func (p *Parser) ParseLoop() AbstractSyntaxTree {
 	return node
 }
 
+/*
+	match     --> MATCH logical_statement LCURLY match_arm+ RCURLY
+	match_arm --> pattern (COMMA pattern)* (IF logical_statement)? FAT_ARROW LCURLY block RCURLY
+*/
+func (p *Parser) MatchStatement() AbstractSyntaxTree {
+	token := p.CurrentToken
+	p.ValidateToken(constants.MATCH)
+
+	subject := p.LogicalStatement()
+	p.ValidateToken(constants.LCURLY)
+
+	node := MatchStatement{
+		Token:   token,
+		Subject: subject,
+	}
+
+	for len(node.Arms) == 0 || p.CurrentToken.Type != constants.RCURLY {
+		arm := MatchArm{
+			Patterns: []AbstractSyntaxTree{p.Pattern()},
+		}
+
+		for p.CurrentToken.Type == constants.COMMA {
+			p.ValidateToken(constants.COMMA)
+			arm.Patterns = append(arm.Patterns, p.Pattern())
+		}
+
+		if p.CurrentToken.Type == constants.IF {
+			p.ValidateToken(constants.IF)
+			arm.Guard = p.LogicalStatement()
+		}
+
+		arm.Token = p.CurrentToken
+		p.ValidateToken(constants.FAT_ARROW)
+
+		p.ValidateToken(constants.LCURLY)
+		arm.Block = p.Program()
+		p.ValidateToken(constants.RCURLY)
+
+		node.Arms = append(node.Arms, arm)
+	}
+
+	p.ValidateToken(constants.RCURLY)
+
+	return node
+}
+
+/*
+	pattern --> WILDCARD | ID | ID DOT ID | literal (RANGE literal)?
+	literal --> MINUS? (INTEGER | FLOAT) | STRING | TRUE | FALSE | NONE
+*/
+func (p *Parser) Pattern() AbstractSyntaxTree {
+	token := p.CurrentToken
+
+	switch token.Type {
+	case constants.WILDCARD:
+		p.ValidateToken(constants.WILDCARD)
+		return WildcardPattern{Token: token}
+
+	case constants.IDENTIFIER:
+		if p.Lexer.PeekNextToken(1).Type == constants.DOT {
+			return p.EnumMember()
+		}
+
+		p.ValidateToken(constants.IDENTIFIER)
+
+		return BindingPattern{
+			Token:   token,
+			Binding: &Binding{},
+		}
+	}
+
+	low := p.PatternLiteral()
+
+	if p.CurrentToken.Type != constants.RANGE {
+		return low
+	}
+
+	token = p.CurrentToken
+	p.ValidateToken(constants.RANGE)
+
+	return RangePattern{
+		Low:   low,
+		Token: token,
+		High:  p.PatternLiteral(),
+	}
+}
+
+// the literals patterns are made of, numbers can be negative. Ex - -1..1
+func (p *Parser) PatternLiteral() AbstractSyntaxTree {
+	if p.CurrentToken.Type != constants.MINUS {
+		if !helpers.ValueInSlice(p.CurrentToken.Type, []string{
+			constants.INTEGER, constants.FLOAT, constants.STRING, constants.TRUE, constants.FALSE, constants.NONE,
+		}) {
+			p.Error(constants.ERROR_UNEXPECTED_TOKEN, p.CurrentToken, "a pattern")
+		}
+
+		return p.Factor()
+	}
+
+	p.ValidateToken(constants.MINUS)
+	token := p.CurrentToken
+
+	switch token.Type {
+	case constants.INTEGER:
+		p.ValidateToken(constants.INTEGER)
+
+		token.IntegerValue = -token.IntegerValue
+
+		return IntegerNumber{Token: token, Value: token.IntegerValue}
+
+	default:
+		p.ValidateToken(constants.FLOAT)
+
+		token.FloatValue = -token.FloatValue
+
+		return FloatNumber{Token: token, Value: token.FloatValue}
+	}
+}
+
 /*
 conditional_statement --> IF logical_statement LCURLY statement_list RCURLY
 (ELIF logical_statement LCURLY statement_list RCURLY)* (ELSE LCURLY statement_list RCURLY){0,1}
@@ -719,16 +1280,8 @@ func (p *Parser) AssignmentStatement() AbstractSyntaxTree {
 	token := p.CurrentToken
 	p.ValidateToken(constants.ASSIGN)
 
-	var right AbstractSyntaxTree
-
-	if p.Lexer.PeekNextToken(1).Type == constants.STRING {
-		right = p.Factor()
-	} else if p.Lexer.PeekNextToken(1).Type == constants.LPAREN {
-		// assignment to function call
-		right = p.FunctionCallStatement()
-	} else {
-		right = p.LogicalStatement()
-	}
+	// function calls and strings are factors, so they can be part of a larger expression. Ex - fib(n - 1) + fib(n - 2)
+	right := p.LogicalStatement()
 
 	// helpers.ColorPrint(
 	// 	constants.LightYellow, 1, 1,
@@ -744,13 +1297,33 @@ func (p *Parser) AssignmentStatement() AbstractSyntaxTree {
 	}
 }
 
+// destructuring_assignment --> variable (COMMA variable)+ ASSIGN logical_statement
+func (p *Parser) DestructuringAssignment() AbstractSyntaxTree {
+	targets := []AbstractSyntaxTree{p.Variable()}
+
+	for p.CurrentToken.Type == constants.COMMA {
+		p.ValidateToken(constants.COMMA)
+		targets = append(targets, p.Variable())
+	}
+
+	token := p.CurrentToken
+	p.ValidateToken(constants.ASSIGN)
+
+	return DestructuringAssignment{
+		Targets: targets,
+		Token:   token,
+		Right:   p.LogicalStatement(),
+	}
+}
+
 /*
 	variable --> ID
 */
 func (p *Parser) Variable() AbstractSyntaxTree {
 	variable := Variable{
-		Token: p.CurrentToken,
-		Value: p.CurrentToken.Value,
+		Token:   p.CurrentToken,
+		Value:   p.CurrentToken.Value,
+		Binding: &Binding{},
 	}
 
 	p.ValidateToken(constants.IDENTIFIER)
@@ -759,5 +1332,21 @@ func (p *Parser) Variable() AbstractSyntaxTree {
 }
 
 func (p *Parser) Parse() AbstractSyntaxTree {
-	return p.Program()
+	program := p.Program()
+
+	// anything left after the statements would never run
+	if p.CurrentToken.Type != constants.EOF {
+		if helpers.ValueInSlice(p.CurrentToken.Type, constants.DECLARATION_KEYWORDS) {
+			errors.ShowError(
+				constants.PARSER_ERROR,
+				constants.ERROR_UNEXPECTED_TOKEN,
+				fmt.Sprintf("Declarations have to come before the statements, found '%s' after them", p.CurrentToken.Value),
+				p.CurrentToken,
+			)
+		}
+
+		p.Error(constants.ERROR_UNEXPECTED_TOKEN, p.CurrentToken, constants.EOF)
+	}
+
+	return program
 }
@@ -23,6 +23,7 @@ func (p Program) Scope(i *Interpreter) {
 		globalScope = &ScopedSymbolsTable{
 			CurrentScopeName:  "global",
 			CurrentScopeLevel: 1,
+			Frame:             &FrameLayout{Level: 1},
 		}
 
 		globalScope.Init()
@@ -32,6 +33,7 @@ func (p Program) Scope(i *Interpreter) {
 		defer i.ReleaseScope()
 
 		i.CurrentScope = globalScope
+		i.Assignments.inFunctions = assignedInFunctions(p)
 	}
 
 	for _, decl := range p.Declarations {
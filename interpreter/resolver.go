@@ -0,0 +1,34 @@
+package interpreter
+
+/*
+	The resolver gives every variable a slot in the activation record of the scope declaring it, and every use
+	of a variable the depth and slot to find it at. It runs as part of scoping.
+*/
+
+// gives the symbol the next slot of the current activation record
+func (i *Interpreter) DeclareSlot(symbol *Symbol) {
+	frame := i.CurrentScope.Frame
+
+	symbol.Slot = frame.Slots
+	symbol.FrameLevel = frame.Level
+
+	frame.Slots++
+}
+
+// fills in where the symbol is stored, relative to the current activation record
+func (i *Interpreter) Resolve(symbol Symbol, binding *Binding) {
+	if binding == nil {
+		return
+	}
+
+	binding.Depth = i.CurrentScope.Frame.Level - symbol.FrameLevel
+	binding.Slot = symbol.Slot
+}
+
+// creates a new scope with its own activation record, release it with ReleaseScope
+func (i *Interpreter) EnterFrame(scopeName string) {
+	level := i.CurrentScope.Frame.Level
+
+	i.EnterScope(scopeName)
+	i.CurrentScope.Frame = &FrameLayout{Level: level + 1}
+}
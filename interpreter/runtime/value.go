@@ -0,0 +1,271 @@
+package runtime
+
+import (
+	"strconv"
+	"strings"
+
+	"programminglang/constants"
+)
+
+// the kind of a runtime value
+type Kind int
+
+const (
+	NIL Kind = iota // no value, ex - what a function without a return value gives back
+	INT
+	FLOAT
+	STRING
+	BOOL
+	LIST
+	FUNCTION
+	TUPLE // the values a function returns together, ex - return q, r;
+	NONE  // the explicit absence of a value, which only optional variables can hold
+	ENUM  // a member of an enum, ex - Color.Red
+)
+
+/*
+	A value a program works with. Only the field for its Kind is set.
+
+	New kinds of values get a Kind, a constructor and a case in each of the methods below
+*/
+type Value struct {
+	Kind  Kind
+	Int   int
+	Float float32
+	Str   string
+	Bool  bool
+	List  []Value // the elements of a list or a tuple
+
+	Function *Function
+	Enum     *Enum // the enum of a member, its ordinal is in Int
+}
+
+// a function as a value, ex - fn(x: int) -> int { return x * 2; }
+type Function struct {
+	Name string // empty for function literals
+	Type string // ex - fn(int) -> int
+
+	// what the engine running the program calls, ex - the function's symbol and the record it was created in
+	Implementation interface{}
+}
+
+// an enum type, its members are numbered from 0 in the order they're declared. Ex - enum Color { Red, Green }
+type Enum struct {
+	Name    string
+	Members []string
+}
+
+var Nil = Value{}
+
+var None = Value{Kind: NONE}
+
+func IntValue(value int) Value {
+	return Value{Kind: INT, Int: value}
+}
+
+func FloatValue(value float32) Value {
+	return Value{Kind: FLOAT, Float: value}
+}
+
+func StringValue(value string) Value {
+	return Value{Kind: STRING, Str: value}
+}
+
+func BoolValue(value bool) Value {
+	return Value{Kind: BOOL, Bool: value}
+}
+
+func ListValue(values []Value) Value {
+	return Value{Kind: LIST, List: values}
+}
+
+func TupleValue(values []Value) Value {
+	return Value{Kind: TUPLE, List: values}
+}
+
+func FunctionValue(function *Function) Value {
+	return Value{Kind: FUNCTION, Function: function}
+}
+
+func EnumValue(enum *Enum, ordinal int) Value {
+	return Value{Kind: ENUM, Int: ordinal, Enum: enum}
+}
+
+func (v Value) IsNil() bool {
+	return v.Kind == NIL
+}
+
+func (v Value) IsNone() bool {
+	return v.Kind == NONE
+}
+
+func (v Value) IsNumber() bool {
+	return v.Kind == INT || v.Kind == FLOAT
+}
+
+// the value of a number as a float, 0 for anything else
+func (v Value) Number() float32 {
+	if v.Kind == INT {
+		return float32(v.Int)
+	}
+
+	return v.Float
+}
+
+// the name of the value's type, ex - "int". Lists don't know the type of their elements, so they're just "[]"
+func (v Value) TypeName() string {
+	switch v.Kind {
+	case INT:
+		return constants.INTEGER_TYPE
+
+	case FLOAT:
+		return constants.FLOAT_TYPE
+
+	case STRING:
+		return constants.STRING_TYPE
+
+	case BOOL:
+		return constants.BOOLEAN_TYPE
+
+	case LIST:
+		return constants.LSQUARE_SYMBOL + constants.RSQUARE_SYMBOL
+
+	case FUNCTION:
+		return v.Function.Type
+
+	case TUPLE:
+		return constants.LPAREN_SYMBOL + constants.RPAREN_SYMBOL
+
+	case NONE:
+		return constants.NONE_TYPE
+
+	case ENUM:
+		return v.Enum.Name
+	}
+
+	return ""
+}
+
+// formats the value the way output() and str() print it
+func (v Value) String() string {
+	switch v.Kind {
+	case INT:
+		return strconv.Itoa(v.Int)
+
+	case FLOAT:
+		return strconv.FormatFloat(float64(v.Float), 'g', -1, 32)
+
+	case STRING:
+		return v.Str
+
+	case BOOL:
+		return strconv.FormatBool(v.Bool)
+
+	case LIST, TUPLE:
+		var elements []string
+
+		for _, element := range v.List {
+			elements = append(elements, element.String())
+		}
+
+		if v.Kind == TUPLE {
+			return constants.LPAREN_SYMBOL + strings.Join(elements, " ") + constants.RPAREN_SYMBOL
+		}
+
+		return constants.LSQUARE_SYMBOL + strings.Join(elements, " ") + constants.RSQUARE_SYMBOL
+
+	case FUNCTION:
+		if v.Function.Name == "" {
+			return "<" + constants.FN + ">"
+		}
+
+		return "<" + constants.FN + " " + v.Function.Name + ">"
+
+	case NONE:
+		return constants.NONE
+
+	// members are printed by name
+	case ENUM:
+		return v.Enum.Members[v.Int]
+	}
+
+	return "<nil>"
+}
+
+// the value as a plain Go value, for the fmt package
+func (v Value) Interface() interface{} {
+	switch v.Kind {
+	case INT:
+		return v.Int
+
+	case FLOAT:
+		return v.Float
+
+	case STRING:
+		return v.Str
+
+	case BOOL:
+		return v.Bool
+
+	case LIST:
+		return Interfaces(v.List)
+
+	case FUNCTION, TUPLE, NONE, ENUM:
+		return v.String()
+	}
+
+	return nil
+}
+
+func Interfaces(values []Value) []interface{} {
+	result := []interface{}{}
+
+	for _, value := range values {
+		result = append(result, value.Interface())
+	}
+
+	return result
+}
+
+// whether two values are the same, ints and floats are compared as numbers
+func (v Value) Equal(other Value) bool {
+	if v.IsNumber() && other.IsNumber() {
+		if v.Kind == INT && other.Kind == INT {
+			return v.Int == other.Int
+		}
+
+		return v.Number() == other.Number()
+	}
+
+	if v.Kind != other.Kind {
+		return false
+	}
+
+	switch v.Kind {
+	case STRING:
+		return v.Str == other.Str
+
+	case BOOL:
+		return v.Bool == other.Bool
+
+	case LIST, TUPLE:
+		if len(v.List) != len(other.List) {
+			return false
+		}
+
+		for index := range v.List {
+			if !v.List[index].Equal(other.List[index]) {
+				return false
+			}
+		}
+
+	// the same function, created in the same place
+	case FUNCTION:
+		return v.Function.Implementation == other.Function.Implementation
+
+	case ENUM:
+		return v.Enum == other.Enum && v.Int == other.Int
+	}
+
+	return true
+}
@@ -36,18 +36,55 @@ func (v AssignmentStatement) GetToken() types.Token {
 }
 func (as AssignmentStatement) Scope(i *Interpreter) {
 	variableName := as.Left.GetToken().Value
-	_, exists := i.CurrentScope.LookupSymbol(variableName, false)
+	symbol := i.assignmentTarget(as.Left)
+
+	expectType(as.Right, symbol.Type)
+	as.Right.Scope(i)
+
+	// a variable only ever holds values of its type, calls through a variable holding a function are checked against it.
+	// Only optionals can hold none, and a tuple has to be destructured, ex - q, r := divmod(7, 2);
+	valueType := i.TypeOf(as.Right)
+
+	if !IsAssignable(symbol.Type, valueType) {
+		errors.ShowError(
+			constants.TYPE_ERROR,
+			constants.ERROR_TYPE_MISMATCH,
+			fmt.Sprintf("Cannot assign a value of type %s to '%s' of type %s", valueType, variableName, symbol.Type),
+			as.Left.GetToken(),
+		)
+	}
+
+	i.MarkAssigned(variableName)
+
+	if left, ok := as.Left.(Variable); ok {
+		i.Resolve(symbol, left.Binding)
+	}
+}
+
+// the symbol of a variable being assigned to, which has to be declared and can't be a constant
+func (i *Interpreter) assignmentTarget(left AbstractSyntaxTree) Symbol {
+	variableName := left.GetToken().Value
+	symbol, exists := i.CurrentScope.LookupSymbol(variableName, false)
 
 	if !exists {
 		errors.ShowError(
 			constants.SEMANTIC_ERROR,
 			constants.ERROR_VARAIBLE_NOT_DEFINED,
 			fmt.Sprintf("AssignmentStatement '%s' is not defined", variableName),
-			as.Left.GetToken(),
+			left.GetToken(),
 		)
 	}
 
-	as.Right.Scope(i)
+	if symbol.Category == constants.CONSTANT_CATEGORY || symbol.Type == constants.ENUM_TYPE {
+		errors.ShowError(
+			constants.SEMANTIC_ERROR,
+			constants.ERROR_ASSIGN_TO_CONSTANT,
+			fmt.Sprintf("Cannot assign to constant '%s'", variableName),
+			left.GetToken(),
+		)
+	}
+
+	return symbol
 }
 
 func (bs BlankStatement) GetToken() types.Token {
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"programminglang/constants"
 	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
 	"programminglang/types"
+	"strings"
 )
 
 type Symbol struct {
@@ -15,6 +17,24 @@ type Symbol struct {
 	ParamSymbols   []Symbol           // all the parameter symbols for functions
 	FunctionBlock  AbstractSyntaxTree // the function's block (executable) code
 	ReturningValue AbstractSyntaxTree
+	ReturnType     string          // statically known type of the value the function returns
+	Native         *NativeFunction // set for functions implemented in Go
+	Value          runtime.Value   // value of a constant or the default of a parameter, known while scoping
+	Variadic       bool            // the last parameter of a function, which gets the rest of the arguments as a list
+	Enum           *runtime.Enum   // the members of an enum type
+	BuiltIn        bool            // a native function or constant, defined in every scope so declarations can shadow it
+
+	Slot       int // where a variable is stored in its activation record
+	FrameLevel int // nesting level of the activation record a variable or function is declared in
+}
+
+/*
+	The activation record the variables of a scope are stored in at runtime. The program, functions and
+	loops get their own, the blocks of conditionals use the one of the scope enclosing them
+*/
+type FrameLayout struct {
+	Level int // nesting level of the activation record
+	Slots int // number of slots given out so far
 }
 
 type ScopedSymbolsTable struct {
@@ -22,6 +42,7 @@ type ScopedSymbolsTable struct {
 	CurrentScopeLevel int
 	EnclosingScope    *ScopedSymbolsTable
 	SymbolTable       map[string]Symbol
+	Frame             *FrameLayout
 }
 
 /*
@@ -51,10 +72,25 @@ func (s *ScopedSymbolsTable) Init() {
 		Type: constants.BUILT_IN_TYPE,
 	})
 
-	s.DefineSymbol(Symbol{
-		Name: constants.PRINT_OUTPUT,
-		Type: constants.FUNCTION_TYPE,
-	})
+	for name, native := range NativeFunctions {
+		s.DefineSymbol(Symbol{
+			Name:       name,
+			Type:       constants.FUNCTION_TYPE,
+			ReturnType: native.ReturnType,
+			Native:     native,
+			BuiltIn:    true,
+		})
+	}
+
+	for name, value := range NativeConstants {
+		s.DefineSymbol(Symbol{
+			Name:     name,
+			Category: constants.CONSTANT_CATEGORY,
+			Type:     value.TypeName(),
+			Value:    value,
+			BuiltIn:  true,
+		})
+	}
 
 }
 
@@ -80,6 +116,75 @@ func (s *ScopedSymbolsTable) LookupSymbol(symbolName string, currentScopeOnly bo
 	return value, ok
 }
 
+// same as LookupSymbol, but also returns the scope the symbol is defined in
+func (s *ScopedSymbolsTable) LookupSymbolScope(symbolName string) (*ScopedSymbolsTable, Symbol, bool) {
+	if value, ok := s.SymbolTable[symbolName]; ok {
+		return s, value, true
+	}
+
+	if s.EnclosingScope == s || s.EnclosingScope == nil {
+		return nil, Symbol{}, false
+	}
+
+	return s.EnclosingScope.LookupSymbolScope(symbolName)
+}
+
+/*
+	Looks up a type by name. Enums are types too. Optional, list, tuple and function types are valid as long as the types they're made of are valid
+*/
+func (s *ScopedSymbolsTable) LookupType(typeName string) (Symbol, bool) {
+	if IsOptionalType(typeName) {
+		if _, ok := s.LookupType(NonOptionalType(typeName)); !ok {
+			return Symbol{}, false
+		}
+
+		return Symbol{Name: typeName, Type: constants.BUILT_IN_TYPE}, true
+	}
+
+	if IsListType(typeName) {
+		if _, ok := s.LookupType(ListElementType(typeName)); !ok {
+			return Symbol{}, false
+		}
+
+		return Symbol{Name: typeName, Type: constants.BUILT_IN_TYPE}, true
+	}
+
+	if IsTupleType(typeName) {
+		for _, elementType := range TupleElementTypes(typeName) {
+			if _, ok := s.LookupType(elementType); !ok {
+				return Symbol{}, false
+			}
+		}
+
+		return Symbol{Name: typeName, Type: constants.BUILT_IN_TYPE}, true
+	}
+
+	if IsFunctionType(typeName) {
+		paramTypes, returnType := FunctionTypeParts(typeName)
+
+		// only the last parameter can be variadic, ex - fn(str, ...int)
+		if last := len(paramTypes) - 1; last >= 0 && IsVariadicType(paramTypes[last]) {
+			paramTypes[last] = strings.TrimPrefix(paramTypes[last], constants.ELLIPSIS_SYMBOL)
+		}
+
+		if returnType != "" {
+			paramTypes = append(paramTypes, returnType)
+		}
+
+		for _, partType := range paramTypes {
+			if _, ok := s.LookupType(partType); !ok {
+				return Symbol{}, false
+			}
+		}
+
+		return Symbol{Name: typeName, Type: constants.BUILT_IN_TYPE}, true
+	}
+
+	symbol, ok := s.LookupSymbol(typeName, false)
+
+	return symbol, ok && (symbol.Type == constants.BUILT_IN_TYPE || symbol.Type == constants.ENUM_TYPE)
+}
+
 func (s *ScopedSymbolsTable) Error(errorCode string, token types.Token) {
 	errors.ShowError(
 		constants.SEMANTIC_ERROR,
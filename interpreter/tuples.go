@@ -0,0 +1,222 @@
+package interpreter
+
+import (
+	"fmt"
+	"strings"
+
+	"programminglang/constants"
+	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
+	"programminglang/types"
+)
+
+// the values a function returns together, ex - return q, r;
+type TupleLiteral struct {
+	Token    types.Token // the RETURN token
+	Elements []AbstractSyntaxTree
+}
+
+// assigns the values of a tuple to a variable each, ex - q, r := divmod(7, 2);
+type DestructuringAssignment struct {
+	Targets []AbstractSyntaxTree // Variable structs
+	Token   types.Token          // the ASSIGN token
+	Right   AbstractSyntaxTree
+}
+
+func (tl TupleLiteral) GetToken() types.Token {
+	return tl.Token
+}
+
+func (tl TupleLiteral) Scope(i *Interpreter) {
+	for _, element := range tl.Elements {
+		element.Scope(i)
+	}
+}
+
+func (i *Interpreter) EvaluateTupleLiteral(tl TupleLiteral) runtime.Value {
+	elements := []runtime.Value{}
+
+	for _, element := range tl.Elements {
+		elements = append(elements, i.Visit(element))
+	}
+
+	return runtime.TupleValue(elements)
+}
+
+func (da DestructuringAssignment) GetToken() types.Token {
+	return da.Token
+}
+
+// every variable is checked like the one of an assignment, against the type of the tuple's value in its position
+func (da DestructuringAssignment) Scope(i *Interpreter) {
+	var symbols []Symbol
+
+	for index, target := range da.Targets {
+		for _, previous := range da.Targets[:index] {
+			if previous.GetToken().Value == target.GetToken().Value {
+				errors.ShowError(
+					constants.SEMANTIC_ERROR,
+					constants.ERROR_DUPLICATE_ID,
+					fmt.Sprintf("'%s' is assigned more than once", target.GetToken().Value),
+					target.GetToken(),
+				)
+			}
+		}
+
+		symbols = append(symbols, i.assignmentTarget(target))
+	}
+
+	da.Right.Scope(i)
+
+	if valueType := i.TypeOf(da.Right); valueType != "" {
+		if !IsTupleType(valueType) {
+			errors.ShowError(
+				constants.TYPE_ERROR,
+				constants.ERROR_TYPE_MISMATCH,
+				fmt.Sprintf("Cannot assign a value of type %s to %d variables", valueType, len(da.Targets)),
+				da.Token,
+			)
+		}
+
+		elementTypes := TupleElementTypes(valueType)
+
+		if len(elementTypes) != len(da.Targets) {
+			errors.ShowError(
+				constants.TYPE_ERROR,
+				constants.ERROR_TYPE_MISMATCH,
+				fmt.Sprintf("Cannot assign %d values to %d variables", len(elementTypes), len(da.Targets)),
+				da.Token,
+			)
+		}
+
+		for index, symbol := range symbols {
+			if !IsAssignable(symbol.Type, elementTypes[index]) {
+				errors.ShowError(
+					constants.TYPE_ERROR,
+					constants.ERROR_TYPE_MISMATCH,
+					fmt.Sprintf("Cannot assign a value of type %s to '%s' of type %s", elementTypes[index], symbol.Name, symbol.Type),
+					da.Targets[index].GetToken(),
+				)
+			}
+		}
+	}
+
+	for index, target := range da.Targets {
+		i.MarkAssigned(symbols[index].Name)
+
+		if variable, ok := target.(Variable); ok {
+			i.Resolve(symbols[index], variable.Binding)
+		}
+	}
+}
+
+func (i *Interpreter) EvaluateDestructuringAssignment(da DestructuringAssignment) runtime.Value {
+	values := unpack(da, i.Visit(da.Right))
+
+	activationRecord, _ := i.CallStack.Peek()
+
+	for index, target := range da.Targets {
+		binding := target.(Variable).Binding
+		activationRecord.SetItem(binding.Depth, binding.Slot, values[index])
+	}
+
+	return runtime.Nil
+}
+
+// the values of the tuple, one for every variable. Only values whose type wasn't known while scoping can be wrong
+func unpack(da DestructuringAssignment, value runtime.Value) []runtime.Value {
+	if value.Kind != runtime.TUPLE || len(value.List) != len(da.Targets) {
+		errors.ShowError(
+			constants.RUNTIME_ERROR,
+			constants.ERROR_TYPE_MISMATCH,
+			fmt.Sprintf("Cannot assign '%v' to %d variables", value, len(da.Targets)),
+			da.Token,
+		)
+	}
+
+	return value.List
+}
+
+// describes a returned value which doesn't match the declared return type, value by value for tuples
+func returnTypeMismatch(functionName, returnType, valueType string) string {
+	if IsTupleType(returnType) && IsTupleType(valueType) {
+		returnTypes, valueTypes := TupleElementTypes(returnType), TupleElementTypes(valueType)
+
+		if len(returnTypes) != len(valueTypes) {
+			return fmt.Sprintf("%s returns %d values, declared to return %d", functionName, len(valueTypes), len(returnTypes))
+		}
+
+		for index := range returnTypes {
+			if !IsAssignable(returnTypes[index], valueTypes[index]) {
+				return fmt.Sprintf(
+					"%s returns a value of type %s as value %d, declared to return %s",
+					functionName, valueTypes[index], index+1, returnTypes[index],
+				)
+			}
+		}
+	}
+
+	return fmt.Sprintf("%s returns a value of type %s, declared to return %s", functionName, valueType, returnType)
+}
+
+// returns the name of the type of a tuple. Ex - [int, str] -> (int, str)
+func TupleTypeOf(elementTypes []string) string {
+	return constants.LPAREN_SYMBOL + strings.Join(elementTypes, constants.COMMA_SYMBOL+" ") + constants.RPAREN_SYMBOL
+}
+
+func IsTupleType(typeName string) bool {
+	return strings.HasPrefix(typeName, constants.LPAREN_SYMBOL) && strings.HasSuffix(typeName, constants.RPAREN_SYMBOL)
+}
+
+// returns the types of the elements of a tuple type. Ex - (int, fn(int, int) -> int) -> [int, fn(int, int) -> int]
+func TupleElementTypes(typeName string) []string {
+	var elementTypes []string
+
+	depth := 0
+	start := len(constants.LPAREN_SYMBOL)
+	end := len(typeName) - len(constants.RPAREN_SYMBOL)
+
+	// the elements can be function, list or tuple types themselves, so only split at the outermost level
+	for index := start; index < end; index++ {
+		switch string(typeName[index]) {
+		case constants.LPAREN_SYMBOL, constants.LSQUARE_SYMBOL:
+			depth++
+
+		case constants.RPAREN_SYMBOL, constants.RSQUARE_SYMBOL:
+			depth--
+
+		case constants.COMMA_SYMBOL:
+			if depth == 0 {
+				elementTypes = append(elementTypes, strings.TrimSpace(typeName[start:index]))
+				start = index + 1
+			}
+		}
+	}
+
+	return append(elementTypes, strings.TrimSpace(typeName[start:end]))
+}
+
+// the positions of a tuple type which hold floats, ints returned there are converted. Ex - (int, float) -> [1]
+func floatPositions(tupleType string) []int {
+	var positions []int
+
+	for position, elementType := range TupleElementTypes(tupleType) {
+		if elementType == constants.FLOAT_TYPE {
+			positions = append(positions, position)
+		}
+	}
+
+	return positions
+}
+
+// a copy of the tuple with the int at position converted to a float
+func tupleToFloat(tuple runtime.Value, position int) runtime.Value {
+	if tuple.List[position].Kind != runtime.INT {
+		return tuple
+	}
+
+	elements := append([]runtime.Value{}, tuple.List...)
+	elements[position] = runtime.FloatValue(float32(elements[position].Int))
+
+	return runtime.TupleValue(elements)
+}
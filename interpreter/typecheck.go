@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"programminglang/constants"
 	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
 	"programminglang/types"
 )
 
@@ -33,105 +34,251 @@ func abstractTypeCheck(leftType, operation, rightType string, opeartionToken typ
 	}
 }
 
-func (i *Interpreter) TypeCheckBinaryOperationNode(b BinaryOperationNode) string {
-	// helpers.ColorPrint(constants.LightCyan, 1, 1, constants.SpewPrinter.Sdump(i.CallStack.Peek()))
-	// helpers.ColorPrint(constants.LightCyan, 1, 1, constants.SpewPrinter.Sdump(b))
+/*
+	Checks that the operation is defined for the values of its evaluated operands.
 
-	leftToken := b.GetLeftOperandToken()
-	rightToken := b.GetRightOperandToken()
+	Returns the token type of the left operand, ex - INTEGER
+*/
+func (i *Interpreter) TypeCheckBinaryOperationNode(b BinaryOperationNode, left, right runtime.Value) string {
+	leftType := valueTokenType(left)
 
-	leftType := leftToken.Type
-	rightType := rightToken.Type
-	operation := b.Operation.Type
+	abstractTypeCheck(leftType, b.Operation.Type, valueTokenType(right), b.Operation)
 
-	if bLeft, ok := b.Left.(BinaryOperationNode); ok {
-		leftType = i.TypeCheckBinaryOperationNode(bLeft)
+	return leftType
+}
+
+func (i *Interpreter) TypeCheckComparisonOperationNode(c ComparisonNode, left, right runtime.Value) string {
+	leftType := valueTokenType(left)
+
+	// any value can be checked for none, ex - x != none. Members of enums are checked while scoping
+	if (left.IsNone() || right.IsNone() || left.Kind == runtime.ENUM) &&
+		(c.Comparator.Type == constants.EQUALITY || c.Comparator.Type == constants.NOT_EQUAL_TO) {
+		return leftType
+	}
+
+	abstractTypeCheck(leftType, c.Comparator.Type, valueTokenType(right), c.Comparator)
+
+	return leftType
+}
+
+// the token type corresponding to a runtime value, ex - 3 -> INTEGER
+func valueTokenType(value runtime.Value) string {
+	switch value.Kind {
+	case runtime.NIL:
+		return ""
+
+	case runtime.LIST:
+		return constants.LIST
+
+	case runtime.FUNCTION:
+		return constants.FUNCTION
 	}
 
-	if bRight, ok := b.Right.(BinaryOperationNode); ok {
-		rightType = i.TypeCheckBinaryOperationNode(bRight)
+	if tokenType, exists := constants.VAR_TYPE_TO_TOKEN_TYPE[value.TypeName()]; exists {
+		return tokenType
 	}
 
-	activationRecord, _ := i.CallStack.Peek()
+	return value.TypeName()
+}
+
+/*
+	Statically works out the type of an expression ("int", "float", "str", "bool", a list type, an optional type
+	or the name of an enum)
+	from the symbols in the current scope.
+
+	Returns an empty string if the type cannot be known before running the program
+*/
+func (i *Interpreter) TypeOf(node AbstractSyntaxTree) string {
+	switch n := node.(type) {
+	case IntegerNumber:
+		return constants.INTEGER_TYPE
+
+	case FloatNumber:
+		return constants.FLOAT_TYPE
+
+	case String:
+		return constants.STRING_TYPE
+
+	case Boolean, ComparisonNode, LogicalNode:
+		return constants.BOOLEAN_TYPE
 
-	// helpers.ColorPrint(
-	// 	constants.Cyan, 1, 1,
-	// 	" leftType = ", leftType,
-	// 	" rightType = ", rightType,
-	// 	" operand = ", operation,
-	// )
+	case NoneLiteral:
+		return constants.NONE_TYPE
 
-	if leftType == constants.IDENTIFIER {
-		// this has "int" and not INTEGER
-		val, _ := activationRecord.GetItem(leftToken.Value)
-		leftType = val[constants.AR_KEY_TYPE].(string)
+	case CoalesceNode:
+		return coalesceType(i.TypeOf(n.Left), i.TypeOf(n.Right))
 
-		if actualType, exists := constants.VAR_TYPE_TO_TOKEN_TYPE[leftType]; exists {
-			leftType = actualType
+	case ConditionalExpression:
+		return n.Binding.Type
+
+	case EnumMember:
+		return n.Binding.Type
+
+	case EnumFromOrdinal:
+		return n.Binding.Type
+
+	case Variable:
+		// the scopes of blocks are gone once they're scoped, so use what was found while scoping
+		if n.Binding != nil && n.Binding.Type != "" {
+			return n.Binding.Type
 		}
-	}
 
-	if rightType == constants.IDENTIFIER {
-		val, _ := activationRecord.GetItem(rightToken.Value)
+		symbol, _ := i.CurrentScope.LookupSymbol(n.Value, false)
+		return symbol.Type
+
+	case UnaryOperationNode:
+		return i.TypeOf(n.Operand)
+
+	case TypeConversion:
+		return n.Token.Value
+
+	case BinaryOperationNode:
+		return binaryOperationType(n.Operation.Type, i.TypeOf(n.Left), i.TypeOf(n.Right))
+
+	case FunctionLiteral:
+		return n.Symbol.Type
+
+	case TupleLiteral:
+		var elementTypes []string
 
-		rightType = val[constants.AR_KEY_TYPE].(string)
+		for _, element := range n.Elements {
+			elementType := i.TypeOf(element)
 
-		if actualType, exists := constants.VAR_TYPE_TO_TOKEN_TYPE[rightType]; exists {
-			rightType = actualType
+			if elementType == "" {
+				return ""
+			}
+
+			elementTypes = append(elementTypes, elementType)
 		}
-	}
 
-	// helpers.ColorPrint(
-	// 	constants.Cyan, 1, 1,
-	// 	" leftToken = ", leftToken,
-	// 	" rightToken = ", rightToken,
-	// 	" operand = ", operation,
-	// )
+		return TupleTypeOf(elementTypes)
 
-	abstractTypeCheck(leftType, operation, rightType, b.Operation)
+	case ListLiteral:
+		if n.Binding.Type != "" {
+			return n.Binding.Type
+		}
 
-	return leftType
-}
+		if len(n.Elements) == 0 {
+			return ListTypeOf("")
+		}
 
-func (i *Interpreter) TypeCheckComparisonOperationNode(c ComparisonNode) string {
-	// helpers.ColorPrint(constants.LightGreen, 1, 1, constants.SpewPrinter.Sdump(c))
+		if elementType := i.TypeOf(n.Elements[0]); elementType != "" {
+			return ListTypeOf(elementType)
+		}
 
-	leftToken := c.GetLeftOperandToken()
-	rightToken := c.GetRightOperandToken()
+	case FunctionCall:
+		symbol, _ := i.CurrentScope.LookupSymbol(n.FunctionName, false)
 
-	leftType := leftToken.Type
-	rightType := rightToken.Type
-	operation := c.Comparator.Type
+		// a parameter or variable holding a function
+		if symbol.Native == nil && symbol.Category != constants.FUNCTION_CATEGORY {
+			_, returnType := FunctionTypeParts(symbol.Type)
+			return returnType
+		}
 
-	// recursive type checking
-	if cLeft, ok := c.Left.(ComparisonNode); ok {
-		leftType = i.TypeCheckComparisonOperationNode(cLeft)
-	} else if cLeft, ok := c.Left.(BinaryOperationNode); ok {
-		leftType = i.TypeCheckBinaryOperationNode(cLeft)
+		if symbol.ReturnType != constants.NUMBER_TYPE {
+			return symbol.ReturnType
+		}
+
+		returnType := constants.INTEGER_TYPE
+
+		for _, param := range n.ActualParameters {
+			switch i.TypeOf(param) {
+			case "":
+				return ""
+			case constants.FLOAT_TYPE:
+				returnType = constants.FLOAT_TYPE
+			}
+		}
+
+		return returnType
+
+	case IndexNode:
+		leftType := i.TypeOf(n.Left)
+
+		if IsListType(leftType) {
+			return ListElementType(leftType)
+		}
+
+		if leftType == constants.STRING_TYPE {
+			return constants.STRING_TYPE
+		}
+	}
+
+	return ""
+}
+
+// the type of the result of a binary operation given the types of its operands
+func binaryOperationType(operation, leftType, rightType string) string {
+	if leftType == "" || rightType == "" {
+		return ""
 	}
 
-	if cRight, ok := c.Right.(ComparisonNode); ok {
-		rightType = i.TypeCheckComparisonOperationNode(cRight)
-	} else if cRight, ok := c.Right.(BinaryOperationNode); ok {
-		rightType = i.TypeCheckBinaryOperationNode(cRight)
+	switch operation {
+	case constants.FLOAT_DIV, constants.EXPONENT:
+		return constants.FLOAT_TYPE
+
+	case constants.INTEGER_DIV, constants.MODULO:
+		return constants.INTEGER_TYPE
 	}
 
-	activationRecord, _ := i.CallStack.Peek()
+	// [1] + [2, 3], the type of an empty list is only known from the other one
+	if IsListType(leftType) && leftType == ListTypeOf("") {
+		return rightType
+	}
 
-	if leftType == constants.IDENTIFIER {
-		// this has "int" and not INTEGER
-		val, _ := activationRecord.GetItem(leftToken.Value)
-		leftType = val[constants.AR_KEY_TYPE].(string)
-		leftType = constants.VAR_TYPE_TO_TOKEN_TYPE[leftType]
+	if IsListType(leftType) {
+		return leftType
 	}
 
-	if rightType == constants.IDENTIFIER {
-		val, _ := activationRecord.GetItem(rightToken.Value)
-		rightType = val[constants.AR_KEY_TYPE].(string)
-		rightType = constants.VAR_TYPE_TO_TOKEN_TYPE[rightType]
+	// "a" + "b" and "a" * 3
+	if leftType == constants.STRING_TYPE {
+		return constants.STRING_TYPE
 	}
 
-	abstractTypeCheck(leftType, operation, rightType, c.Comparator)
+	if leftType == constants.FLOAT_TYPE || rightType == constants.FLOAT_TYPE {
+		return constants.FLOAT_TYPE
+	}
 
 	return leftType
 }
+
+// whether a value of type sourceType can be stored in a variable of type targetType, ints can be stored as floats
+func IsAssignable(targetType, sourceType string) bool {
+	if sourceType == "" || targetType == sourceType {
+		return true
+	}
+
+	// an optional holds none or a value of its type, ex - let port: int? = 8080;
+	if IsOptionalType(targetType) {
+		return sourceType == constants.NONE_TYPE || IsAssignable(NonOptionalType(targetType), NonOptionalType(sourceType))
+	}
+
+	// an optional can't be stored in a variable that is never none, ex - let port: int = none;
+	if MightBeNone(sourceType) {
+		return false
+	}
+
+	// an empty list, ex - let names: [str] = [];
+	if IsListType(targetType) && sourceType == ListTypeOf("") {
+		return true
+	}
+
+	// every value of a tuple has to be assignable to the type in its position, ex - (float, int) and (int, int)
+	if IsTupleType(targetType) && IsTupleType(sourceType) {
+		targetTypes, sourceTypes := TupleElementTypes(targetType), TupleElementTypes(sourceType)
+
+		if len(targetTypes) != len(sourceTypes) {
+			return false
+		}
+
+		for index := range targetTypes {
+			if !IsAssignable(targetTypes[index], sourceTypes[index]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return targetType == constants.FLOAT_TYPE && sourceType == constants.INTEGER_TYPE
+}
@@ -1,13 +1,19 @@
 package interpreter
 
 import (
+	"fmt"
+
 	"programminglang/constants"
+	"programminglang/interpreter/errors"
+	"programminglang/interpreter/runtime"
 	"programminglang/types"
 )
 
 type VariableDeclaration struct {
 	VariableNode AbstractSyntaxTree // a Variable struct
-	TypeNode     AbstractSyntaxTree // a VariableType struct
+	TypeNode     AbstractSyntaxTree // a VariableType struct, nil if the type is inferred from the Initializer
+	Initializer  AbstractSyntaxTree // optional, ex - let x: int = 5;
+	Constant     bool               // declared with const, the Initializer has to be known while scoping
 }
 
 type VariableType struct {
@@ -18,22 +24,77 @@ type Variable struct {
 	Token   types.Token
 	Value   string
 	VarType *AbstractSyntaxTree
+	Binding *Binding // filled in while scoping, nil for variables being declared
 }
 
 func (v VariableDeclaration) GetToken() types.Token {
 	return types.Token{}
 }
 func (v VariableDeclaration) Scope(i *Interpreter) {
-	typeName := v.TypeNode.GetToken().Value
+	variableName := v.VariableNode.GetToken().Value
 
-	typeSymbol, _ := i.CurrentScope.LookupSymbol(typeName, false)
+	var initializerType string
 
-	variableName := v.VariableNode.GetToken().Value
+	// scoped before the variable is defined, so the initializer can't refer to the variable itself
+	if v.Initializer != nil {
+		if v.TypeNode != nil {
+			expectType(v.Initializer, v.TypeNode.GetToken().Value)
+		}
+
+		v.Initializer.Scope(i)
+		initializerType = i.TypeOf(v.Initializer)
+	}
+
+	var typeSymbol Symbol
+
+	if v.TypeNode != nil {
+		var typeExists bool
+
+		typeSymbol, typeExists = i.CurrentScope.LookupType(v.TypeNode.GetToken().Value)
+
+		if !typeExists {
+			i.CurrentScope.Error(
+				constants.ERROR_ID_NOT_FOUND,
+				v.TypeNode.GetToken(),
+			)
+		}
+
+		if v.Initializer != nil && !IsAssignable(typeSymbol.Name, initializerType) {
+			errors.ShowError(
+				constants.TYPE_ERROR,
+				constants.ERROR_TYPE_MISMATCH,
+				fmt.Sprintf("Cannot initialize '%s' of type %s with a value of type %s", variableName, typeSymbol.Name, initializerType),
+				v.VariableNode.GetToken(),
+			)
+		}
+	} else {
+		if initializerType == "" {
+			errors.ShowError(
+				constants.SEMANTIC_ERROR,
+				constants.ERROR_TYPE_INFERENCE,
+				fmt.Sprintf("Cannot infer the type of '%s', declare it as let %s: type", variableName, variableName),
+				v.VariableNode.GetToken(),
+			)
+		}
+
+		// let x = none; doesn't say what x holds when it isn't none
+		if initializerType == constants.NONE_TYPE {
+			errors.ShowError(
+				constants.SEMANTIC_ERROR,
+				constants.ERROR_TYPE_INFERENCE,
+				fmt.Sprintf("Cannot infer the type of '%s' from none, declare it as let %s: type?", variableName, variableName),
+				v.VariableNode.GetToken(),
+			)
+		}
+
+		typeSymbol = Symbol{Name: initializerType}
+	}
 
 	// helpers.ColorPrint(constants.Green, 1, v.VariableNode)
 	// helpers.ColorPrint(constants.Green, 1, typeSymbol)
 
-	if _, exists := i.CurrentScope.LookupSymbol(variableName, true); exists {
+	// built in functions and constants can be shadowed
+	if symbol, exists := i.CurrentScope.LookupSymbol(variableName, true); exists && !symbol.BuiltIn {
 		// variable alreadyDeclaredVarName has already been declared
 		i.CurrentScope.Error(
 			constants.ERROR_DUPLICATE_ID,
@@ -46,6 +107,14 @@ func (v VariableDeclaration) Scope(i *Interpreter) {
 		Type: typeSymbol.Name,
 	}
 
+	if v.Constant {
+		symbol.Category = constants.CONSTANT_CATEGORY
+		symbol.Value = i.constantValue(v, symbol.Type)
+	}
+
+	i.DeclareSlot(&symbol)
+	i.Resolve(symbol, v.VariableNode.(Variable).Binding)
+
 	// helpers.ColorPrint(
 	// 	constants.Green, 1, 1,
 	// 	"defining symbol", constants.SpewPrinter.Sdump(symbol),
@@ -56,6 +125,12 @@ func (v VariableDeclaration) Scope(i *Interpreter) {
 
 	i.CurrentScope.DefineSymbol(symbol)
 
+	// optionals start out as none
+	if v.Initializer != nil || IsOptionalType(symbol.Type) {
+		i.MarkAssigned(variableName)
+	} else {
+		i.DeclareUnassigned(variableName)
+	}
 }
 
 func (v VariableType) GetToken() types.Token {
@@ -77,4 +152,63 @@ func (v Variable) Scope(i *Interpreter) {
 		)
 	}
 
+	i.CheckAssigned(v)
+
+	if v.Binding != nil {
+		symbol, _ := i.CurrentScope.LookupSymbol(varName, false)
+
+		if symbol.Type == constants.ENUM_TYPE {
+			errors.ShowError(
+				constants.SEMANTIC_ERROR,
+				constants.ERROR_UNSUPPORTED,
+				fmt.Sprintf("Enum '%s' is a type, use one of its members, ex - %s.%s", varName, varName, symbol.Enum.Members[0]),
+				v.Token,
+			)
+		}
+
+		if symbol.Native != nil {
+			errors.ShowError(
+				constants.SEMANTIC_ERROR,
+				constants.ERROR_UNSUPPORTED,
+				fmt.Sprintf("Built in function '%s' can only be called, wrap it in a fn to use it as a value", varName),
+				v.Token,
+			)
+		}
+
+		v.Binding.Type = symbol.Type
+		i.Resolve(symbol, v.Binding)
+
+		if symbol.Category == constants.CONSTANT_CATEGORY {
+			v.Binding.Constant = true
+			v.Binding.Value = symbol.Value
+		}
+	}
+}
+
+// folds the initializer of a constant declaration to its value
+func (i *Interpreter) constantValue(v VariableDeclaration, constantType string) runtime.Value {
+	name := v.VariableNode.GetToken().Value
+
+	var value runtime.Value
+	var ok bool
+
+	if v.Initializer != nil {
+		value, ok = literalValue(i.FoldConstants(v.Initializer))
+	}
+
+	if !ok {
+		errors.ShowError(
+			constants.SEMANTIC_ERROR,
+			constants.ERROR_NOT_CONSTANT,
+			fmt.Sprintf("Constant '%s' has to be initialized with a value known before the program runs", name),
+			v.VariableNode.GetToken(),
+		)
+	}
+
+	// const RATIO: float = 1;
+	if value.Kind == runtime.INT && constantType == constants.FLOAT_TYPE {
+		value = runtime.FloatValue(float32(value.Int))
+	}
+
+	return value
 }
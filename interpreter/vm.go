@@ -0,0 +1,301 @@
+package interpreter
+
+import (
+	"programminglang/constants"
+	"programminglang/interpreter/runtime"
+)
+
+// the frame of a function being run by the virtual machine
+type frame struct {
+	Function *CompiledFunction
+	IP       int          // index of the next instruction
+	Env      *environment // where the variables in scope are
+}
+
+// the slots of a function call or loop iteration, kept for as long as a function created in it is around
+type environment struct {
+	Slots     []runtime.Value
+	Enclosing *environment // the environment the function was created in, or the one around the loop
+}
+
+// a function value of the virtual machine, the function and the environment it was created in
+type vmClosure struct {
+	Function *CompiledFunction
+	Env      *environment
+}
+
+/*
+	A stack based virtual machine running the bytecode from Compile.
+
+	Operators are applied by the same functions the tree walker uses, so both engines give the same results
+	and report the same errors
+*/
+type VirtualMachine struct {
+	Interpreter *Interpreter
+	Bytecode    *Bytecode
+	Stack       []runtime.Value // the operand stack
+	Frames      []*frame
+}
+
+func (i *Interpreter) RunBytecode(bytecode *Bytecode) runtime.Value {
+	vm := VirtualMachine{
+		Interpreter: i,
+		Bytecode:    bytecode,
+	}
+
+	return vm.Run()
+}
+
+func (vm *VirtualMachine) push(value runtime.Value) {
+	vm.Stack = append(vm.Stack, value)
+}
+
+func (vm *VirtualMachine) pop() runtime.Value {
+	value := vm.Stack[len(vm.Stack)-1]
+	vm.Stack = vm.Stack[:len(vm.Stack)-1]
+
+	return value
+}
+
+// pops the two operands of a binary operation, the right one is on top
+func (vm *VirtualMachine) popPair() (runtime.Value, runtime.Value) {
+	right := vm.pop()
+	left := vm.pop()
+
+	return left, right
+}
+
+func (vm *VirtualMachine) enter(function *CompiledFunction, enclosing *environment) *frame {
+	callFrame := &frame{
+		Function: function,
+		Env:      &environment{Slots: make([]runtime.Value, function.Slots), Enclosing: enclosing},
+	}
+
+	vm.Frames = append(vm.Frames, callFrame)
+
+	return callFrame
+}
+
+// the main program has the first frame, every other one is a function call
+func (vm *VirtualMachine) checkCallDepth(f FunctionCall) {
+	if len(vm.Frames)-1 == constants.MAX_CALL_DEPTH {
+		callDepthExceeded(f)
+	}
+}
+
+// the environment depth levels above this one, 0 is the environment itself
+func (env *environment) ancestor(depth int) *environment {
+	for ; depth > 0; depth-- {
+		env = env.Enclosing
+	}
+
+	return env
+}
+
+func (vm *VirtualMachine) Run() runtime.Value {
+	i := vm.Interpreter
+	bytecode := vm.Bytecode
+
+	current := vm.enter(bytecode.Main, nil)
+	globals := current.Env.Slots
+
+	for {
+		instruction := current.Function.Code[current.IP]
+		current.IP++
+
+		switch instruction.Op {
+		case OP_CONSTANT:
+			vm.push(bytecode.Constants[instruction.A])
+
+		case OP_NIL:
+			vm.push(runtime.Nil)
+
+		case OP_POP:
+			vm.pop()
+
+		case OP_GET_LOCAL, OP_GET_GLOBAL, OP_GET_OUTER:
+			slots := current.Env.Slots
+
+			switch instruction.Op {
+			case OP_GET_GLOBAL:
+				slots = globals
+
+			case OP_GET_OUTER:
+				slots = current.Env.ancestor(instruction.C).Slots
+			}
+
+			value := slots[instruction.A]
+
+			// the declared but never assigned variables which the scoping pass couldn't catch
+			if value.IsNil() && instruction.B >= 0 {
+				usedBeforeAssignment(bytecode.Nodes[instruction.B].(Variable))
+			}
+
+			vm.push(value)
+
+		case OP_SET_LOCAL:
+			current.Env.Slots[instruction.A] = vm.pop()
+
+		case OP_SET_GLOBAL:
+			globals[instruction.A] = vm.pop()
+
+		case OP_SET_OUTER:
+			current.Env.ancestor(instruction.C).Slots[instruction.A] = vm.pop()
+
+		case OP_TO_FLOAT:
+			switch top := vm.Stack[len(vm.Stack)-1]; {
+			case instruction.B > 0:
+				vm.Stack[len(vm.Stack)-1] = tupleToFloat(top, instruction.B-1)
+
+			case top.Kind == runtime.INT:
+				vm.Stack[len(vm.Stack)-1] = runtime.FloatValue(float32(top.Int))
+
+			// a list spread into a variadic float parameter
+			case top.Kind == runtime.LIST:
+				vm.Stack[len(vm.Stack)-1] = toFloats(top)
+			}
+
+		case OP_UNARY:
+			vm.push(unaryOperation(bytecode.Nodes[instruction.A].(UnaryOperationNode), vm.pop()))
+
+		case OP_BINARY:
+			left, right := vm.popPair()
+			vm.push(i.binaryOperation(bytecode.Nodes[instruction.A].(BinaryOperationNode), left, right))
+
+		case OP_COMPARE:
+			left, right := vm.popPair()
+			vm.push(i.comparison(bytecode.Nodes[instruction.A].(ComparisonNode), left, right))
+
+		case OP_LOGICAL:
+			left, right := vm.popPair()
+			vm.push(logicalOperation(bytecode.Nodes[instruction.A].(LogicalNode), left, right))
+
+		case OP_INDEX:
+			left, right := vm.popPair()
+			vm.push(indexInto(bytecode.Nodes[instruction.A].(IndexNode), left, right))
+
+		case OP_CONVERT:
+			vm.push(convert(bytecode.Nodes[instruction.A].(TypeConversion), vm.pop()))
+
+		case OP_FROM_ORDINAL:
+			vm.push(fromOrdinal(bytecode.Nodes[instruction.A].(EnumFromOrdinal), vm.pop()))
+
+		case OP_JUMP:
+			current.IP = instruction.A
+
+		case OP_JUMP_IF_FALSE:
+			if !vm.pop().Bool {
+				current.IP = instruction.A
+			}
+
+		case OP_JUMP_IF_NOT_NONE:
+			if vm.Stack[len(vm.Stack)-1].IsNone() {
+				vm.pop()
+			} else {
+				current.IP = instruction.A
+			}
+
+		case OP_JUMP_IF_DECIDED:
+			if decidesLogical(bytecode.Nodes[instruction.B].(LogicalNode), vm.Stack[len(vm.Stack)-1]) {
+				current.IP = instruction.A
+			}
+
+		case OP_MATCH:
+			arm := bytecode.Nodes[instruction.A].(MatchStatement).Arms[instruction.B]
+			vm.push(runtime.BoolValue(arm.matches(vm.pop())))
+
+		case OP_LOOP_BOUND:
+			vm.push(runtime.IntValue(loopBound(vm.pop())))
+
+		case OP_LOOP_TEST:
+			if current.Env.Slots[instruction.A].Int > current.Env.Slots[instruction.A+1].Int {
+				current.IP = instruction.B
+			}
+
+		case OP_INCREMENT:
+			current.Env.Slots[instruction.A].Int++
+
+		case OP_ENTER:
+			current.Env = &environment{Slots: make([]runtime.Value, instruction.A), Enclosing: current.Env}
+
+		case OP_LEAVE:
+			current.Env = current.Env.Enclosing
+
+		case OP_CLOSURE:
+			function := bytecode.Functions[instruction.A]
+
+			vm.push(runtime.FunctionValue(&runtime.Function{
+				Name:           function.Name,
+				Type:           function.Type,
+				Implementation: &vmClosure{Function: function, Env: current.Env.ancestor(instruction.C)},
+			}))
+
+		case OP_CALL:
+			function := bytecode.Functions[instruction.A]
+
+			vm.checkCallDepth(bytecode.Nodes[instruction.B].(FunctionCall))
+
+			args := vm.Stack[len(vm.Stack)-function.Params:]
+			vm.Stack = vm.Stack[:len(vm.Stack)-function.Params]
+
+			current = vm.enter(function, current.Env.ancestor(instruction.C))
+			copy(current.Env.Slots, args)
+
+		case OP_CALL_VALUE:
+			closure := vm.pop().Function.Implementation.(*vmClosure)
+			function := closure.Function
+			argCount := instruction.B
+
+			vm.checkCallDepth(bytecode.Nodes[instruction.A].(FunctionCall))
+
+			args := vm.Stack[len(vm.Stack)-argCount:]
+			vm.Stack = vm.Stack[:len(vm.Stack)-argCount]
+
+			current = vm.enter(function, closure.Env)
+			copy(current.Env.Slots, args[:function.Params])
+
+		case OP_ARRANGE:
+			f := bytecode.Nodes[instruction.A].(FunctionCall)
+			argCount := instruction.B
+
+			args := f.Binding.ArrangeArguments(vm.Stack[len(vm.Stack)-argCount:])
+			vm.Stack = append(vm.Stack[:len(vm.Stack)-argCount], args...)
+
+		case OP_CALL_NATIVE:
+			f := bytecode.Nodes[instruction.A].(FunctionCall)
+			argCount := len(f.ActualParameters)
+
+			var args []runtime.Value
+			args = append(args, vm.Stack[len(vm.Stack)-argCount:]...)
+			vm.Stack = vm.Stack[:len(vm.Stack)-argCount]
+
+			vm.push(NativeFunctions[f.FunctionName].Call(i, f, args))
+
+		case OP_LIST:
+			elements := append([]runtime.Value{}, vm.Stack[len(vm.Stack)-instruction.A:]...)
+			vm.Stack = vm.Stack[:len(vm.Stack)-instruction.A]
+
+			vm.push(runtime.ListValue(elements))
+
+		case OP_TUPLE:
+			elements := append([]runtime.Value{}, vm.Stack[len(vm.Stack)-instruction.A:]...)
+			vm.Stack = vm.Stack[:len(vm.Stack)-instruction.A]
+
+			vm.push(runtime.TupleValue(elements))
+
+		case OP_UNPACK:
+			vm.Stack = append(vm.Stack, unpack(bytecode.Nodes[instruction.A].(DestructuringAssignment), vm.pop())...)
+
+		case OP_RETURN:
+			result := vm.pop()
+			vm.Frames = vm.Frames[:len(vm.Frames)-1]
+
+			if len(vm.Frames) == 0 {
+				return result
+			}
+
+			current = vm.Frames[len(vm.Frames)-1]
+			vm.push(result)
+		}
+	}
+}
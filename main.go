@@ -2,12 +2,12 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"os"
 
 	"programminglang/constants"
-	"programminglang/helpers"
 	"programminglang/interpreter"
 	"programminglang/types"
 )
@@ -32,8 +32,12 @@ func getUserInput(reader *bufio.Reader, langInterpreter interpreter.Interpreter)
 		langInterpreter.Init(userInput, false)
 		result := langInterpreter.Interpret()
 
-		if result != nil {
-			helpers.ColorPrint(constants.LightYellow, 1, 1, result)
+		if langInterpreter.Exited {
+			os.Exit(langInterpreter.ExitCode)
+		}
+
+		if !result.IsNil() {
+			langInterpreter.ColorPrint(constants.LightYellow, "\n", result, "\n")
 		}
 	}
 }
@@ -57,21 +61,73 @@ func interpretFile(langInterpreter interpreter.Interpreter, fileName string) {
 
 	result := langInterpreter.Interpret()
 
-	if result != nil {
-		helpers.ColorPrint(constants.LightYellow, 1, 1, result)
+	if langInterpreter.Exited {
+		os.Exit(langInterpreter.ExitCode)
+	}
+
+	if !result.IsNil() {
+		langInterpreter.ColorPrint(constants.LightYellow, "\n", result, "\n")
 	}
 }
 
 func main() {
+	colorMode := flag.String(
+		"color",
+		constants.COLOR_AUTO,
+		fmt.Sprintf("colorize output: %s, %s or %s", constants.COLOR_AUTO, constants.COLOR_ALWAYS, constants.COLOR_NEVER),
+	)
+
+	fileRoot := flag.String("fs-root", "", "allow scripts to access files inside this directory")
+
+	optLevel := flag.Int(
+		"opt-level",
+		constants.OPT_LEVEL_FULL,
+		fmt.Sprintf("%d to only replace constants with their values, %d to also fold and simplify expressions", constants.OPT_LEVEL_NONE, constants.OPT_LEVEL_FULL),
+	)
+
+	engine := flag.String(
+		"engine",
+		constants.ENGINE_TREE,
+		fmt.Sprintf("how to run the program: %s walks the syntax tree, %s compiles it to bytecode", constants.ENGINE_TREE, constants.ENGINE_VM),
+	)
+
+	flag.Parse()
+
+	if *engine != constants.ENGINE_TREE && *engine != constants.ENGINE_VM {
+		fmt.Printf("Invalid --engine %s, expected %s or %s\n", *engine, constants.ENGINE_TREE, constants.ENGINE_VM)
+		os.Exit(2)
+	}
+
+	if *colorMode != constants.COLOR_AUTO && *colorMode != constants.COLOR_ALWAYS && *colorMode != constants.COLOR_NEVER {
+		fmt.Printf(
+			"Invalid --color %s, expected %s, %s or %s\n",
+			*colorMode, constants.COLOR_AUTO, constants.COLOR_ALWAYS, constants.COLOR_NEVER,
+		)
+		os.Exit(2)
+	}
+
+	if *optLevel < constants.OPT_LEVEL_NONE || *optLevel > constants.OPT_LEVEL_FULL {
+		fmt.Printf("Invalid --opt-level %d, expected %d or %d\n", *optLevel, constants.OPT_LEVEL_NONE, constants.OPT_LEVEL_FULL)
+		os.Exit(2)
+	}
+
 	reader := bufio.NewReader(os.Stdin)
-	langInterpreter := interpreter.Interpreter{}
+	langInterpreter := interpreter.Interpreter{
+		ColorMode: *colorMode,
+		Stdin:     reader,
+		FileRoot:  *fileRoot,
+		OptLevel:  *optLevel,
+		Engine:    *engine,
+	}
 	langInterpreter.InitConcrete()
 
-	args := os.Args
+	args := flag.Args()
 
-	if len(args) == 1 {
+	if len(args) == 0 {
 		getUserInput(reader, langInterpreter)
 	} else {
-		interpretFile(langInterpreter, args[1])
+		// everything after the script path is handed over to the script, see args()
+		langInterpreter.Args = args[1:]
+		interpretFile(langInterpreter, args[0])
 	}
 }
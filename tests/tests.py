@@ -2,13 +2,87 @@
 1. Build the binary
 2. Test all code samples in code folder
 3. Any that has an exit code of not zero, the test didn't pass
+4. Run every code sample on both engines, at every optimization level, and compare what they print
+5. The samples which stop with an error have to report the expected error
+6. The samples which call exit(code) have to exit with the expected status
+7. The samples with an expected output have to print exactly that, and --color=never never prints color codes
 """
 
 import subprocess
 import os
+import shutil
 import sys
+import tempfile
 
-TEST_FILE_NAMES = ["primes", "factorial", "fibonacci"]
+TEST_FILE_NAMES = ["primes", "factorial", "fibonacci", "expressions", "recursion", "functions", "closures", "defaults", "variadic", "tuples", "optionals", "enums", "match", "ternary", "files", "math", "stdin", "conversions", "declarations", "constants", "folding"]
+
+# samples which are expected to stop with an error, and what the error has to say
+EXPECTED_ERRORS = {
+    "errors": "RuntimeError: variable 'x' used before assignment",
+    "arguments": "TypeError: scale expects argument 2 to be fn(int) -> int, got fn(int) -> str",
+    "unchecked": "TypeError: A value of type int? might be none",
+    "tuple_assign": "TypeError: Cannot assign a value of type (int, int) to 'q' of type int",
+    "enum_assign": "TypeError: Cannot assign a value of type Shape to 'c' of type Color",
+    "env_default": "TypeError: env expects argument 2 to be str, got int",
+    "int_overflow": "RuntimeError: Cannot convert '1e+30' to int, out of the range of int. Line: 5, Column: 12",
+    "narrowing": "TypeError: A value of type int? might be none, check it with != none or give it a default with ?? first. Line: 10, Column: 18",
+    "modulo_zero": "RuntimeError: Cannot divide by zero. Line: 5, Column: 19",
+    "sandbox_link": "RuntimeError: write_file: 'dangle' is outside of the root directory",
+    "sandbox_parent": "RuntimeError: append_file: 'nested/../../outside.txt' is outside of the root directory",
+    "files_disabled": "RuntimeError: file_exists: file access is disabled. Line: 2, Column: 20",
+    "printf_verb": "TypeError: printf: verb '%d' cannot format argument 1 of type str. Line: 5, Column: 7",
+    "printf_count": "RuntimeError: sprintf: format '%s and %s' has 2 verbs but 1 arguments were given. Line: 5, Column: 16",
+    "parse_error": "RuntimeError: parse_int: 'abc' is not a valid int. Line: 5, Column: 31",
+    "sqrt_domain": "RuntimeError: sqrt is not defined for -1. Line: 5, Column: 13",
+    "log_domain": "RuntimeError: log is not defined for 0. Line: 5, Column: 12",
+    "math_type": "TypeError: floor expects argument 1 to be number, got str. Line: 3, Column: 14",
+    "round_overflow": "RuntimeError: round of '1e+30' is out of the range of int. Line: 5, Column: 14",
+    "convert_text": "RuntimeError: Cannot convert '12 apples' to int, not a valid integer. Line: 5, Column: 12",
+    "convert_list": "TypeError: Cannot convert [int] to int. Line: 5, Column: 12",
+    "declaration_type": "TypeError: Cannot initialize 'count' of type int with a value of type str. Line: 2, Column: 10",
+    "inferred_type": "TypeError: Cannot assign a value of type str to 'count' of type int. Line: 4, Column: 6",
+    "unassigned": "SemanticError: variable 'total' used before assignment. Line: 5, Column: 14",
+    "loop_unassigned": "RuntimeError: variable 'last' used before assignment. Line: 5, Column: 17",
+    "const_assign": "SemanticError: Cannot assign to constant 'LIMIT'. Line: 5, Column: 6",
+    "const_value": "SemanticError: Constant 'LIMIT' has to be initialized with a value known before the program runs. Line: 3, Column: 12",
+    "late_declaration": "ParseError: Declarations have to come before the statements, found 'let' after them. Line: 4, Column: 4",
+    "call_depth": "RuntimeError: Maximum call depth of 5000 exceeded calling count. Line: 3, Column: 17",
+    "concat_functions": "TypeError: Cannot add a value of type int to a list of fn() -> int. Line: 2, Column: 58",
+    "concat_lists": "TypeError: Cannot add a list of type [str] to a list of type [int]. Line: 6, Column: 17",
+    "literal_default": "ParseError: Parameter 'y' of a fn literal can't have a default value. Line: 2, Column: 22",
+}
+
+ERROR_FILE_NAMES = list(EXPECTED_ERRORS)
+
+# samples which stop with exit(code), and the status they have to exit with
+EXPECTED_EXIT = {
+    "exit_status": 3,
+}
+
+EXIT_FILE_NAMES = list(EXPECTED_EXIT)
+
+# samples which have to print exactly this with --color=never
+EXPECTED_OUTPUT = {
+    "plain_output": "count: 3, ratio: 4.5\ndone: true [1 2 3]\n\nno separator3between4.5\n",
+    "printing": (
+        "ab1 2\n"
+        "a b 1 2\n"
+        "a,b,1,2\n"
+        "widgets | 3 | 12.5;\n"
+        "no newline\n"
+        "widgets   |    3|   12.50\n"
+        '[1 2] 12.5 true "widgets" ff %\n'
+        "widgets,3,12.5 14\n"
+    ),
+}
+
+OUTPUT_FILE_NAMES = list(EXPECTED_OUTPUT)
+
+# the start of every ANSI color code
+COLOR_CODE = "\x1b["
+
+ENGINES = ["tree", "vm"]
+OPT_LEVELS = ["0", "1"]
 
 BASE_DIR = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
 EXECUTABLE = os.path.join(BASE_DIR, "main.go")
@@ -20,19 +94,131 @@ BINARY_NAME = f"{BUILD_PATH}/{OUTPUT_NAME}"
 
 CODE_PATH = os.path.join(BASE_DIR, "code")
 
-output = subprocess.run(["go", "build", "-o", BINARY_NAME, EXECUTABLE])
+output = subprocess.run(["go", "build", "-o", BINARY_NAME, EXECUTABLE], cwd=BASE_DIR)
 
 if output.returncode != 0:
     print("Failed to create go binary")
     sys.exit(1)
 
+failed = []
+
+# the root directory of the file samples, holding a symlink to a file outside of it which must never be created
+SANDBOX_PATH = tempfile.mkdtemp()
+FS_ROOT = os.path.join(SANDBOX_PATH, "root")
+OUTSIDE_FILE = os.path.join(SANDBOX_PATH, "outside.txt")
+
+os.mkdir(FS_ROOT)
+os.symlink(OUTSIDE_FILE, os.path.join(FS_ROOT, "dangle"))
+
+# command line flags of the samples which need more than the defaults
+FLAGS = {
+    "files": [f"--fs-root={FS_ROOT}"],
+    "sandbox_link": [f"--fs-root={FS_ROOT}"],
+    "sandbox_parent": [f"--fs-root={FS_ROOT}"],
+}
+
+# arguments passed to the samples after their path, see args()
+ARGS = {
+    "exit_status": ["first", "second"],
+}
+
+# what is piped into the stdin of the samples, the others read an empty input
+STDIN = {
+    "stdin": "ada\n3\n1\n2.5\n4\nthe rest\nof the input\n",
+    "parse_error": "12\nabc\n3\n",
+}
+
+# the environment of every sample, see env()
+ENVIRONMENT = {**os.environ, "LANG_SAMPLE": "sample"}
+
+
+def run(file_name, engine, opt_level):
+    return subprocess.run(
+        [
+            BINARY_NAME,
+            "--color=never",
+            f"--engine={engine}",
+            f"--opt-level={opt_level}",
+            *FLAGS.get(file_name, []),
+            os.path.join(CODE_PATH, file_name),
+            *ARGS.get(file_name, []),
+        ],
+        input=STDIN.get(file_name, ""),
+        capture_output=True,
+        text=True,
+        env=ENVIRONMENT,
+    )
+
+
 for file_name in TEST_FILE_NAMES:
     print(
         f"\n====================== Executing {file_name} ================================"
     )
 
-    execution = subprocess.run([BINARY_NAME, os.path.join(CODE_PATH, file_name)])
+    execution = subprocess.run(
+        [
+            BINARY_NAME,
+            *FLAGS.get(file_name, []),
+            os.path.join(CODE_PATH, file_name),
+            *ARGS.get(file_name, []),
+        ],
+        input=STDIN.get(file_name, ""),
+        text=True,
+        env=ENVIRONMENT,
+    )
+
+    if execution.returncode != 0:
+        failed.append(f"{file_name} exited with {execution.returncode}")
 
     print(
         f"==================== Finished Executing {file_name} =========================\n"
     )
+
+for file_name, expected in EXPECTED_ERRORS.items():
+    result = run(file_name, ENGINES[0], OPT_LEVELS[0])
+
+    if result.returncode == 0 or expected not in result.stderr:
+        failed.append(f"{file_name} didn't stop with {expected}")
+
+for file_name, expected in EXPECTED_EXIT.items():
+    result = run(file_name, ENGINES[0], OPT_LEVELS[0])
+
+    if result.returncode != expected:
+        failed.append(f"{file_name} exited with {result.returncode} instead of {expected}")
+
+for file_name, expected in EXPECTED_OUTPUT.items():
+    result = run(file_name, ENGINES[0], OPT_LEVELS[0])
+
+    if result.returncode != 0 or result.stdout != expected:
+        failed.append(f"{file_name} printed {result.stdout!r} instead of {expected!r}")
+
+# differential test, the tree walker is the reference implementation
+for file_name in TEST_FILE_NAMES + ERROR_FILE_NAMES + EXIT_FILE_NAMES + OUTPUT_FILE_NAMES:
+    reference = run(file_name, ENGINES[0], OPT_LEVELS[0])
+
+    if COLOR_CODE in reference.stdout or COLOR_CODE in reference.stderr:
+        failed.append(f"{file_name} printed color codes with --color=never")
+
+    for engine in ENGINES:
+        for opt_level in OPT_LEVELS:
+            result = run(file_name, engine, opt_level)
+
+            if (result.stdout, result.stderr, result.returncode) != (
+                reference.stdout,
+                reference.stderr,
+                reference.returncode,
+            ):
+                failed.append(
+                    f"{file_name} differs with --engine={engine} --opt-level={opt_level}"
+                )
+
+if os.path.exists(OUTSIDE_FILE):
+    failed.append("a sample wrote outside of its --fs-root")
+
+shutil.rmtree(SANDBOX_PATH)
+
+if failed:
+    print("\n".join(["FAILED"] + failed))
+    sys.exit(1)
+
+print("All tests passed")